@@ -0,0 +1,217 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/wilgnert/webtutoria/internal/database"
+)
+
+// --- Handler for /students-subjects/{id}/amendments (List, Amend) ---
+func (c *Config) CompletionAmendmentsHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		c.listCompletionAmendments(w, r, int32(id))
+	case http.MethodPost:
+		c.amendStudentSubjectCompletion(w, r, int32(id))
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// checkCompletionDate enforces the backdating policy: completions cannot be
+// dated in the future or further back than the configured window.
+func (c *Config) checkCompletionDate(completedAt time.Time) error {
+	window := c.BackdateWindow
+	now := time.Now()
+	if completedAt.After(now) {
+		return fmt.Errorf("completed_at cannot be in the future")
+	}
+	if completedAt.Before(now.Add(-window)) {
+		return fmt.Errorf("completed_at cannot be more than %d days ago", int(window.Hours()/24))
+	}
+	return nil
+}
+
+func (c *Config) listCompletionAmendments(w http.ResponseWriter, r *http.Request, id int32) {
+	if _, err := c.DB.GetStudentSubjectCompletionByID(r.Context(), id); err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "Student Subject Completion not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to get student subject completion: %v", err), http.StatusInternalServerError)
+		return
+	}
+	amendments, err := c.DB.ListCompletionAmendments(r.Context(), id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list completion amendments: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, amendments)
+}
+
+// amendStudentSubjectCompletion handles POST requests to
+// /students-subjects/{id}/amendments. It corrects the completion in place and
+// keeps one amendment row per changed field with the old and new values.
+func (c *Config) amendStudentSubjectCompletion(w http.ResponseWriter, r *http.Request, id int32) {
+	var reqPayload struct {
+		Reason      string     `json:"reason"`
+		CompletedAt *time.Time `json:"completed_at"`
+		Grade       *float64   `json:"grade"`
+	}
+	if err := DecodeJSON(r.Body, &reqPayload); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if reqPayload.Reason == "" {
+		http.Error(w, "A reason is required to amend a completion", http.StatusBadRequest)
+		return
+	}
+	if reqPayload.CompletedAt == nil && reqPayload.Grade == nil {
+		http.Error(w, "Nothing to amend", http.StatusBadRequest)
+		return
+	}
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	completion, err := c.DB.GetStudentSubjectCompletionByID(r.Context(), id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "Student Subject Completion not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to get student subject completion: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if err := c.checkActsOn(r.Context(), by, completion.StudentID); err != nil {
+		actorError(w, err)
+		return
+	}
+
+	// Everything is checked before anything is written
+	var revision, term sql.NullInt32
+	if reqPayload.CompletedAt != nil {
+		if err := c.checkCompletionDate(*reqPayload.CompletedAt); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// The new date may fall under a different revision of the subject
+		revision, err = c.subjectRevisionAt(r.Context(), completion.SubjectID, *reqPayload.CompletedAt)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to get subject revision: %v", err), http.StatusInternalServerError)
+			return
+		}
+		// and to a different term
+		term, err = c.termFor(r.Context(), nil, *reqPayload.CompletedAt)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to get term: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+	var passed bool
+	if reqPayload.Grade != nil {
+		if *reqPayload.Grade < 0 || *reqPayload.Grade > 100 {
+			http.Error(w, "grade must be between 0 and 100", http.StatusBadRequest)
+			return
+		}
+		subject, err := c.DB.GetSubjectByID(r.Context(), completion.SubjectID)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to get subject: %v", err), http.StatusInternalServerError)
+			return
+		}
+		passed = !subject.PassingGrade.Valid || *reqPayload.Grade >= subject.PassingGrade.Float64
+	}
+
+	err = c.inTx(r.Context(), func(q *database.Queries) error {
+		record := func(field string, oldValue, newValue sql.NullString) error {
+			_, err := q.CreateCompletionAmendment(r.Context(), database.CreateCompletionAmendmentParams{
+				CompletionID:     id,
+				Field:            field,
+				OldValue:         oldValue,
+				NewValue:         newValue,
+				Reason:           reqPayload.Reason,
+				AmendedByTutorID: by.TutorID,
+				AmendedBy:        by.Name,
+			})
+			return err
+		}
+		if reqPayload.CompletedAt != nil {
+			err := q.UpdateStudentSubjectCompletionDate(r.Context(), database.UpdateStudentSubjectCompletionDateParams{
+				CompletedAt: sql.NullTime{Time: *reqPayload.CompletedAt, Valid: true},
+				ID:          id,
+			})
+			if err != nil {
+				return err
+			}
+			err = q.UpdateStudentSubjectCompletionRevision(r.Context(), database.UpdateStudentSubjectCompletionRevisionParams{
+				RevisionID: revision,
+				ID:         id,
+			})
+			if err != nil {
+				return err
+			}
+			err = q.UpdateStudentSubjectCompletionTerm(r.Context(), database.UpdateStudentSubjectCompletionTermParams{
+				TermID: term,
+				ID:     id,
+			})
+			if err != nil {
+				return err
+			}
+			oldValue := sql.NullString{}
+			if completion.CompletedAt.Valid {
+				oldValue = sql.NullString{String: completion.CompletedAt.Time.Format(time.RFC3339), Valid: true}
+			}
+			newValue := sql.NullString{String: reqPayload.CompletedAt.Format(time.RFC3339), Valid: true}
+			if err := record("completed_at", oldValue, newValue); err != nil {
+				return err
+			}
+		}
+		if reqPayload.Grade != nil {
+			err := q.UpdateStudentSubjectCompletionGrade(r.Context(), database.UpdateStudentSubjectCompletionGradeParams{
+				Grade:  sql.NullFloat64{Float64: *reqPayload.Grade, Valid: true},
+				Passed: passed,
+				ID:     id,
+			})
+			if err != nil {
+				return err
+			}
+			oldValue := sql.NullString{}
+			if completion.Grade.Valid {
+				oldValue = sql.NullString{String: strconv.FormatFloat(completion.Grade.Float64, 'f', -1, 64), Valid: true}
+			}
+			newValue := sql.NullString{String: strconv.FormatFloat(*reqPayload.Grade, 'f', -1, 64), Valid: true}
+			if err := record("grade", oldValue, newValue); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to amend completion: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if reqPayload.Grade != nil {
+		// A passing grade may complete the rules of the student's class
+		if err := c.evaluateClassProgression(r.Context(), completion.StudentID, by); err != nil {
+			http.Error(w, fmt.Sprintf("Failed to evaluate class progression: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	completion, err = c.DB.GetStudentSubjectCompletionByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to retrieve amended completion: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, completion)
+}
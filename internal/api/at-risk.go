@@ -0,0 +1,464 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/wilgnert/webtutoria/internal/database"
+)
+
+const (
+	riskLow    = "low"
+	riskMedium = "medium"
+	riskHigh   = "high"
+
+	riskInactive       = "inactive"
+	riskSlowPace       = "slow_pace"
+	riskMissedSessions = "missed_sessions"
+	riskGoalSlippage   = "goal_slippage"
+)
+
+// RiskThresholds decide when a factor counts against a student and how many
+// factors make a risk level. Each factor that fires adds one to the score.
+type RiskThresholds struct {
+	// Days without a completion or study session
+	InactiveDays int
+	// Passes are counted over this window to get a student's pace
+	PaceWindow time.Duration
+	// Pace below this share of the cohort median is slow
+	PaceRatio float64
+	// Weeks looked back over for missed sessions
+	SessionWeeks int
+	// Weeks without a study session that count against a student
+	MissedSessions int
+	// Overdue or at-risk goals that count against a student
+	SlippingGoals int
+	// Scores at which a student becomes medium and high risk
+	MediumScore int
+	HighScore   int
+}
+
+var defaultRiskThresholds = RiskThresholds{
+	InactiveDays:   14,
+	PaceWindow:     28 * 24 * time.Hour,
+	PaceRatio:      0.5,
+	SessionWeeks:   4,
+	MissedSessions: 2,
+	SlippingGoals:  1,
+	MediumScore:    1,
+	HighScore:      2,
+}
+
+// read overrides the thresholds given in the "at_risk" section of
+// config.json.
+func (t *RiskThresholds) read(section map[string]any) {
+	number := func(key string) (float64, bool) {
+		v, ok := section[key].(float64)
+		return v, ok
+	}
+	if v, ok := number("inactive_days"); ok {
+		t.InactiveDays = int(v)
+	}
+	if v, ok := number("pace_window_days"); ok {
+		t.PaceWindow = time.Duration(v * float64(24*time.Hour))
+	}
+	if v, ok := number("pace_ratio"); ok {
+		t.PaceRatio = v
+	}
+	if v, ok := number("session_weeks"); ok {
+		t.SessionWeeks = int(v)
+	}
+	if v, ok := number("missed_sessions"); ok {
+		t.MissedSessions = int(v)
+	}
+	if v, ok := number("slipping_goals"); ok {
+		t.SlippingGoals = int(v)
+	}
+	if v, ok := number("medium_score"); ok {
+		t.MediumScore = int(v)
+	}
+	if v, ok := number("high_score"); ok {
+		t.HighScore = int(v)
+	}
+}
+
+// validate rejects thresholds that would flag everyone or no one. Missed
+// sessions and slipping goals may be 0 to switch those factors off.
+func (t RiskThresholds) validate() error {
+	switch {
+	case t.InactiveDays <= 0:
+		return errors.New("inactive_days must be positive")
+	case t.PaceWindow <= 0:
+		return errors.New("pace_window_days must be positive")
+	case t.PaceRatio <= 0:
+		return errors.New("pace_ratio must be positive")
+	case t.SessionWeeks <= 0:
+		return errors.New("session_weeks must be positive")
+	case t.MissedSessions < 0:
+		return errors.New("missed_sessions cannot be negative")
+	case t.SlippingGoals < 0:
+		return errors.New("slipping_goals cannot be negative")
+	case t.MediumScore < 1:
+		return errors.New("medium_score must be at least 1")
+	case t.HighScore < t.MediumScore:
+		return errors.New("high_score cannot be below medium_score")
+	}
+	return nil
+}
+
+func (t RiskThresholds) level(score int) string {
+	switch {
+	case score >= t.HighScore:
+		return riskHigh
+	case score >= t.MediumScore:
+		return riskMedium
+	default:
+		return riskLow
+	}
+}
+
+var riskRank = map[string]int{riskLow: 0, riskMedium: 1, riskHigh: 2}
+
+type riskFactor struct {
+	Factor    string  `json:"factor"`
+	Value     float64 `json:"value"`
+	Threshold float64 `json:"threshold"`
+	Detail    string  `json:"detail"`
+}
+
+type studentRisk struct {
+	StudentID        int32        `json:"student_id"`
+	Name             string       `json:"name"`
+	CohortID         *int32       `json:"cohort_id"`
+	Level            string       `json:"level"`
+	Score            int          `json:"score"`
+	LastActiveAt     *time.Time   `json:"last_active_at"`
+	DaysInactive     *int         `json:"days_inactive"`
+	Pace             int          `json:"pace"`
+	CohortMedianPace float64      `json:"cohort_median_pace"`
+	MissedSessions   int          `json:"missed_sessions"`
+	SlippingGoals    int          `json:"slipping_goals"`
+	Factors          []riskFactor `json:"factors"`
+	ScoredAt         time.Time    `json:"scored_at"`
+}
+
+// latestCohorts maps each student to the cohort of the latest term they are
+// enrolled in; students outside any cohort are missing from the map.
+func (c *Config) latestCohorts(ctx context.Context) (map[int32]int32, error) {
+	terms, err := c.DB.ListTerms(ctx)
+	if err != nil {
+		return nil, err
+	}
+	startsOn := map[int32]time.Time{}
+	for _, t := range terms {
+		startsOn[t.ID] = t.StartsOn
+	}
+	cohorts, err := c.DB.ListCohorts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	latest := map[int32]int32{}
+	seenStart := map[int32]time.Time{}
+	for _, cohort := range cohorts {
+		members, err := c.DB.ListCohortStudents(ctx, cohort.ID)
+		if err != nil {
+			return nil, err
+		}
+		start := startsOn[cohort.TermID]
+		for _, m := range members {
+			if _, ok := latest[m.StudentID]; !ok || start.After(seenStart[m.StudentID]) {
+				latest[m.StudentID] = cohort.ID
+				seenStart[m.StudentID] = start
+			}
+		}
+	}
+	return latest, nil
+}
+
+func median(values []int) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return float64(sorted[mid])
+	}
+	return float64(sorted[mid-1]+sorted[mid]) / 2
+}
+
+// scoreStudents rates every active student against the thresholds. Pace is
+// the number of subjects passed within the pace window, compared with the
+// median of the student's latest cohort, or of all active students for those
+// without one. A missed session is a week in the look-back with no study
+// session logged.
+func (c *Config) scoreStudents(ctx context.Context, t RiskThresholds, now time.Time) ([]studentRisk, error) {
+	studs, err := c.DB.GetAllStudents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	completions, err := c.DB.ListStudentSubjectCompletions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := c.DB.ListStudySessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	subjects, err := c.DB.ListSubjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cohortOf, err := c.latestCohorts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	paceSince := now.Add(-t.PaceWindow)
+	lastActive := map[int32]time.Time{}
+	pace := map[int32]int{}
+	for _, comp := range completions {
+		if !comp.CompletedAt.Valid {
+			continue
+		}
+		if comp.CompletedAt.Time.After(lastActive[comp.StudentID]) {
+			lastActive[comp.StudentID] = comp.CompletedAt.Time
+		}
+		if comp.Passed && comp.CompletedAt.Time.After(paceSince) {
+			pace[comp.StudentID]++
+		}
+	}
+	sessionWeeks := map[int32]map[int]bool{}
+	for _, s := range sessions {
+		if s.StartedAt.After(lastActive[s.StudentID]) {
+			lastActive[s.StudentID] = s.StartedAt
+		}
+		week := int(now.Sub(s.StartedAt) / (7 * 24 * time.Hour))
+		if week >= 0 && week < t.SessionWeeks {
+			if sessionWeeks[s.StudentID] == nil {
+				sessionWeeks[s.StudentID] = map[int]bool{}
+			}
+			sessionWeeks[s.StudentID][week] = true
+		}
+	}
+
+	active := []database.Student{}
+	peerPaces := map[int32][]int{}
+	var allPaces []int
+	for _, stud := range studs {
+		if stud.Status != studentActive {
+			continue
+		}
+		active = append(active, stud)
+		allPaces = append(allPaces, pace[stud.ID])
+		if cohort, ok := cohortOf[stud.ID]; ok {
+			peerPaces[cohort] = append(peerPaces[cohort], pace[stud.ID])
+		}
+	}
+
+	risks := []studentRisk{}
+	for _, stud := range active {
+		risk := studentRisk{
+			StudentID: stud.ID,
+			Name:      stud.Name,
+			Pace:      pace[stud.ID],
+			Factors:   []riskFactor{},
+			ScoredAt:  now,
+		}
+
+		since, ok := lastActive[stud.ID]
+		if ok {
+			risk.LastActiveAt = &since
+		} else if stud.EnrolledOn.Valid {
+			since = stud.EnrolledOn.Time
+		}
+		if !since.IsZero() {
+			days := int(now.Sub(since).Hours() / 24)
+			risk.DaysInactive = &days
+			if days >= t.InactiveDays {
+				risk.Factors = append(risk.Factors, riskFactor{
+					Factor:    riskInactive,
+					Value:     float64(days),
+					Threshold: float64(t.InactiveDays),
+					Detail:    fmt.Sprintf("no completion or study session in %d days", days),
+				})
+			}
+		} else {
+			risk.Factors = append(risk.Factors, riskFactor{
+				Factor:    riskInactive,
+				Threshold: float64(t.InactiveDays),
+				Detail:    "no completion or study session recorded",
+			})
+		}
+
+		peers := allPaces
+		if cohort, ok := cohortOf[stud.ID]; ok {
+			risk.CohortID = &cohort
+			peers = peerPaces[cohort]
+		}
+		risk.CohortMedianPace = median(peers)
+		if risk.CohortMedianPace > 0 && float64(risk.Pace) < risk.CohortMedianPace*t.PaceRatio {
+			risk.Factors = append(risk.Factors, riskFactor{
+				Factor:    riskSlowPace,
+				Value:     float64(risk.Pace),
+				Threshold: risk.CohortMedianPace * t.PaceRatio,
+				Detail: fmt.Sprintf("passed %d subjects in %d days against a cohort median of %g",
+					risk.Pace, int(t.PaceWindow.Hours()/24), risk.CohortMedianPace),
+			})
+		}
+
+		for week := 0; week < t.SessionWeeks; week++ {
+			weekStart := now.Add(-time.Duration(week+1) * 7 * 24 * time.Hour)
+			if stud.EnrolledOn.Valid && weekStart.Before(stud.EnrolledOn.Time) {
+				break
+			}
+			if !sessionWeeks[stud.ID][week] {
+				risk.MissedSessions++
+			}
+		}
+		if t.MissedSessions > 0 && risk.MissedSessions >= t.MissedSessions {
+			risk.Factors = append(risk.Factors, riskFactor{
+				Factor:    riskMissedSessions,
+				Value:     float64(risk.MissedSessions),
+				Threshold: float64(t.MissedSessions),
+				Detail:    fmt.Sprintf("no study session in %d of the last %d weeks", risk.MissedSessions, t.SessionWeeks),
+			})
+		}
+
+		goals, err := c.studentGoalViews(ctx, stud.ID, subjects, now)
+		if err != nil {
+			return nil, err
+		}
+		var overdue int
+		for _, g := range goals {
+			switch g.Status {
+			case goalOverdue:
+				overdue++
+				risk.SlippingGoals++
+			case goalAtRisk:
+				risk.SlippingGoals++
+			}
+		}
+		if t.SlippingGoals > 0 && risk.SlippingGoals >= t.SlippingGoals {
+			risk.Factors = append(risk.Factors, riskFactor{
+				Factor:    riskGoalSlippage,
+				Value:     float64(risk.SlippingGoals),
+				Threshold: float64(t.SlippingGoals),
+				Detail:    fmt.Sprintf("%d goals overdue, %d at risk", overdue, risk.SlippingGoals-overdue),
+			})
+		}
+
+		risk.Score = len(risk.Factors)
+		risk.Level = t.level(risk.Score)
+		risks = append(risks, risk)
+	}
+	return risks, nil
+}
+
+// ScoreStudents is the at-risk scoring job. It scores every active student
+// and replaces the stored levels in one transaction, so the report never
+// mixes two runs.
+func (c *Config) ScoreStudents(ctx context.Context) error {
+	risks, err := c.scoreStudents(ctx, c.AtRisk, time.Now())
+	if err != nil {
+		return err
+	}
+	return c.inTx(ctx, func(q *database.Queries) error {
+		if err := q.DeleteStudentRiskScores(ctx); err != nil {
+			return err
+		}
+		for _, risk := range risks {
+			detail, err := json.Marshal(risk)
+			if err != nil {
+				return err
+			}
+			err = q.CreateStudentRiskScore(ctx, database.CreateStudentRiskScoreParams{
+				StudentID: risk.StudentID,
+				Level:     risk.Level,
+				Score:     int32(risk.Score),
+				Detail:    string(detail),
+				ScoredAt:  risk.ScoredAt,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RunRiskScoring runs ScoreStudents now and then every RiskScoringInterval
+// until ctx is done. A failed run is reported and retried at the next tick.
+func (c *Config) RunRiskScoring(ctx context.Context) {
+	ticker := time.NewTicker(c.RiskScoringInterval)
+	defer ticker.Stop()
+	for {
+		if err := c.ScoreStudents(ctx); err != nil {
+			fmt.Printf("error scoring at-risk students: %v\n", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// --- Handler for /reports/at-risk ---
+// GET lists the students stored by the last scoring run at or above
+// ?min_level (medium by default), highest score first, with the factors
+// behind each score. Takes the usual ?term_id and ?cohort_id scope. POST
+// runs the scoring job first, for when the last run is too old.
+func (c *Config) AtRiskReportHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		if err := c.ScoreStudents(r.Context()); err != nil {
+			http.Error(w, fmt.Sprintf("Failed to score students: %v", err), http.StatusInternalServerError)
+			return
+		}
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	minLevel := riskMedium
+	if level := r.URL.Query().Get("min_level"); level != "" {
+		if _, ok := riskRank[level]; !ok {
+			http.Error(w, fmt.Sprintf("Unknown level %q", level), http.StatusBadRequest)
+			return
+		}
+		minLevel = level
+	}
+	s, err := c.scopeFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	scores, err := c.DB.ListStudentRiskScores(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list risk scores: %v", err), http.StatusInternalServerError)
+		return
+	}
+	report := []studentRisk{}
+	for _, score := range scores {
+		if !s.hasStudent(score.StudentID) || riskRank[score.Level] < riskRank[minLevel] {
+			continue
+		}
+		var risk studentRisk
+		if err := json.Unmarshal([]byte(score.Detail), &risk); err != nil {
+			http.Error(w, fmt.Sprintf("Failed to read risk score: %v", err), http.StatusInternalServerError)
+			return
+		}
+		report = append(report, risk)
+	}
+	sort.SliceStable(report, func(i, j int) bool {
+		return report[i].Score > report[j].Score
+	})
+	respondWithJSON(w, http.StatusOK, report)
+}
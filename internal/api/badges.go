@@ -0,0 +1,392 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wilgnert/webtutoria/internal/database"
+)
+
+const (
+	badgeCompletions = "completions"
+	badgeCategory    = "category"
+	badgeStreak      = "streak"
+
+	eventBadgeAwarded = "badge.awarded"
+)
+
+type badgeBody struct {
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Rule        string `json:"rule"`
+	CategoryID  *int32 `json:"category_id"`
+	Threshold   *int32 `json:"threshold"`
+	Announce    bool   `json:"announce"`
+}
+
+func (c *Config) validateBadgeBody(ctx context.Context, body *badgeBody) error {
+	body.Code = strings.TrimSpace(body.Code)
+	if body.Code == "" || body.Name == "" {
+		return fmt.Errorf("code and name are required")
+	}
+	if body.Threshold == nil {
+		one := int32(1)
+		body.Threshold = &one
+	}
+	if *body.Threshold < 1 {
+		return fmt.Errorf("threshold must be at least 1")
+	}
+	switch body.Rule {
+	case badgeCompletions:
+	case badgeCategory:
+		if body.CategoryID == nil {
+			return fmt.Errorf("category badges need a category_id")
+		}
+	case badgeStreak:
+		if body.CategoryID != nil {
+			return fmt.Errorf("streak badges do not take a category_id")
+		}
+	default:
+		return fmt.Errorf("Unknown rule %q", body.Rule)
+	}
+	if body.CategoryID != nil {
+		if _, err := c.DB.GetCategoryByID(ctx, *body.CategoryID); err != nil {
+			return fmt.Errorf("Unknown category %d", *body.CategoryID)
+		}
+	}
+	return nil
+}
+
+func (body badgeBody) categoryID() sql.NullInt32 {
+	if body.CategoryID == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: *body.CategoryID, Valid: true}
+}
+
+// weekStreak returns the longest run of consecutive weeks, Monday to Sunday,
+// with at least one of the given dates in each.
+func weekStreak(dates []time.Time) int {
+	weeks := map[int]bool{}
+	for _, d := range dates {
+		day := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)
+		// the Unix epoch fell on a Thursday; shift so weeks start on Monday
+		weeks[int(day.Unix()/86400+3)/7] = true
+	}
+	longest := 0
+	for week := range weeks {
+		if weeks[week-1] {
+			continue
+		}
+		run := 0
+		for weeks[week+run] {
+			run++
+		}
+		longest = max(longest, run)
+	}
+	return longest
+}
+
+// awardBadges checks every badge the student does not hold yet against
+// their passed completions and awards the ones now earned, tying each award
+// to the completion that triggered it. Each award is committed with its
+// badge.awarded event; for badges marked announce the event carries the
+// Discord role and channel of the student's tutor. A badge that fails to be
+// awarded is checked again on the student's next completion.
+func (c *Config) awardBadges(ctx context.Context, studentID, completionID int32) error {
+	badges, err := c.DB.ListBadges(ctx)
+	if err != nil || len(badges) == 0 {
+		return err
+	}
+	held, err := c.DB.ListStudentBadges(ctx, studentID)
+	if err != nil {
+		return err
+	}
+	has := map[int32]bool{}
+	for _, h := range held {
+		has[h.BadgeID] = true
+	}
+	completions, err := c.DB.ListStudentSubjectCompletionsByStudent(ctx, studentID)
+	if err != nil {
+		return err
+	}
+	passed := map[int32]bool{}
+	var passDates []time.Time
+	for _, comp := range completions {
+		if comp.Passed {
+			passed[comp.SubjectID] = true
+			if comp.CompletedAt.Valid {
+				passDates = append(passDates, comp.CompletedAt.Time)
+			}
+		}
+	}
+	var tree *categoryTree
+	inCategory := func(categoryID int32) (map[int32]bool, error) {
+		if tree == nil {
+			t, err := c.loadCategoryTree(ctx)
+			if err != nil {
+				return nil, err
+			}
+			tree = &t
+		}
+		return c.subjectsInCategory(ctx, *tree, categoryID)
+	}
+
+	for _, badge := range badges {
+		if has[badge.ID] {
+			continue
+		}
+		earned := false
+		switch badge.Rule {
+		case badgeCompletions:
+			count := len(passed)
+			if badge.CategoryID.Valid {
+				subjects, err := inCategory(badge.CategoryID.Int32)
+				if err != nil {
+					return err
+				}
+				count = 0
+				for subjectID := range subjects {
+					if passed[subjectID] {
+						count++
+					}
+				}
+			}
+			earned = count >= int(badge.Threshold)
+		case badgeCategory:
+			subjects, err := inCategory(badge.CategoryID.Int32)
+			if err != nil {
+				return err
+			}
+			earned = len(subjects) > 0
+			for subjectID := range subjects {
+				if !passed[subjectID] {
+					earned = false
+					break
+				}
+			}
+		case badgeStreak:
+			earned = weekStreak(passDates) >= int(badge.Threshold)
+		}
+		if !earned {
+			continue
+		}
+		payload := map[string]any{
+			"student_id":    studentID,
+			"badge_id":      badge.ID,
+			"code":          badge.Code,
+			"name":          badge.Name,
+			"completion_id": completionID,
+			"announce":      badge.Announce,
+		}
+		if badge.Announce {
+			routing, err := c.discordRouting(ctx, studentID)
+			if err != nil && err != sql.ErrNoRows {
+				return err
+			}
+			if err == nil {
+				payload["discord"] = routing
+			}
+		}
+		err = c.inTx(ctx, func(q *database.Queries) error {
+			result, err := q.AwardBadge(ctx, database.AwardBadgeParams{
+				StudentID:    studentID,
+				BadgeID:      badge.ID,
+				CompletionID: sql.NullInt32{Int32: completionID, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+			n, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+			// another completion may have awarded it in the meantime; skip
+			// the event and go on with the other badges
+			if n == 0 {
+				return nil
+			}
+			return emitEventWith(ctx, q, eventBadgeAwarded, studentID, payload)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// --- Handler for /badges (List, Create) ---
+func (c *Config) BadgesHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		c.listBadges(w, r)
+	case http.MethodPost:
+		c.createBadge(w, r)
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// --- Handler for /badges/{id} (Get, Update, Delete) ---
+func (c *Config) BadgeByIDHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		c.getBadgeById(w, r, int32(id))
+	case http.MethodPut:
+		c.updateBadgeById(w, r, int32(id))
+	case http.MethodDelete:
+		c.deleteBadgeById(w, r, int32(id))
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// --- Handler for /students/{id}/badges ---
+func (c *Config) StudentBadgesHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if _, err := c.DB.GetStudentByID(r.Context(), int32(id)); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	badges, err := c.DB.ListStudentBadges(r.Context(), int32(id))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list student badges: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if badges == nil {
+		badges = []database.ListStudentBadgesRow{}
+	}
+	respondWithJSON(w, http.StatusOK, badges)
+}
+
+func (c *Config) listBadges(w http.ResponseWriter, r *http.Request) {
+	badges, err := c.DB.ListBadges(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list badges: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if badges == nil {
+		badges = []database.Badge{}
+	}
+	respondWithJSON(w, http.StatusOK, badges)
+}
+
+// createBadge handles POST requests to /badges. Badges are awarded as
+// completions come in, so students who already meet a new badge's rule get
+// it with their next completion.
+func (c *Config) createBadge(w http.ResponseWriter, r *http.Request) {
+	var body badgeBody
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := c.validateBadgeBody(r.Context(), &body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	result, err := c.DB.CreateBadge(r.Context(), database.CreateBadgeParams{
+		Code:        body.Code,
+		Name:        body.Name,
+		Description: sql.NullString{String: body.Description, Valid: body.Description != ""},
+		Rule:        body.Rule,
+		CategoryID:  body.categoryID(),
+		Threshold:   *body.Threshold,
+		Announce:    body.Announce,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			http.Error(w, "code already exists", http.StatusConflict)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to create badge: %v", err), http.StatusInternalServerError)
+		return
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to retrieve new badge ID: %v", err), http.StatusInternalServerError)
+		return
+	}
+	badge, err := c.DB.GetBadgeByID(r.Context(), int32(id))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get badge: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, badge)
+}
+
+func (c *Config) getBadgeById(w http.ResponseWriter, r *http.Request, id int32) {
+	badge, err := c.DB.GetBadgeByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, badge)
+}
+
+// updateBadgeById handles PUT requests to /badges/{id}. Badges already
+// awarded are kept even if the new rule would not award them.
+func (c *Config) updateBadgeById(w http.ResponseWriter, r *http.Request, id int32) {
+	if _, err := c.DB.GetBadgeByID(r.Context(), id); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	var body badgeBody
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := c.validateBadgeBody(r.Context(), &body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err := c.DB.UpdateBadge(r.Context(), database.UpdateBadgeParams{
+		Code:        body.Code,
+		Name:        body.Name,
+		Description: sql.NullString{String: body.Description, Valid: body.Description != ""},
+		Rule:        body.Rule,
+		CategoryID:  body.categoryID(),
+		Threshold:   *body.Threshold,
+		Announce:    body.Announce,
+		ID:          id,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			http.Error(w, "code already exists", http.StatusConflict)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to update badge: %v", err), http.StatusInternalServerError)
+		return
+	}
+	c.getBadgeById(w, r, id)
+}
+
+func (c *Config) deleteBadgeById(w http.ResponseWriter, r *http.Request, id int32) {
+	if _, err := c.DB.GetBadgeByID(r.Context(), id); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if err := c.DB.DeleteBadge(r.Context(), id); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to delete badge: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
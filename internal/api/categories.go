@@ -0,0 +1,355 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/wilgnert/webtutoria/internal/database"
+)
+
+type categoryNode struct {
+	ID            int32           `json:"id"`
+	Name          string          `json:"name"`
+	ParentID      *int32          `json:"parent_id"`
+	Subjects      int             `json:"subjects"`
+	TotalSubjects int             `json:"total_subjects"`
+	Completed     int             `json:"completed"`
+	Children      []*categoryNode `json:"children"`
+}
+
+// categoryTree indexes the categories by id and by parent for walking the
+// hierarchy in memory.
+type categoryTree struct {
+	byID     map[int32]database.Category
+	children map[int32][]int32
+	roots    []int32
+}
+
+func (c *Config) loadCategoryTree(ctx context.Context) (categoryTree, error) {
+	cats, err := c.DB.GetAllCategories(ctx)
+	if err != nil {
+		return categoryTree{}, err
+	}
+	tree := categoryTree{byID: map[int32]database.Category{}, children: map[int32][]int32{}}
+	for _, cat := range cats {
+		tree.byID[cat.ID] = cat
+		if cat.ParentID.Valid {
+			tree.children[cat.ParentID.Int32] = append(tree.children[cat.ParentID.Int32], cat.ID)
+		} else {
+			tree.roots = append(tree.roots, cat.ID)
+		}
+	}
+	return tree, nil
+}
+
+// descendants returns the category and every category below it.
+func (t categoryTree) descendants(id int32) map[int32]bool {
+	seen := map[int32]bool{}
+	stack := []int32{id}
+	for len(stack) > 0 {
+		cur := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if seen[cur] {
+			continue
+		}
+		seen[cur] = true
+		stack = append(stack, t.children[cur]...)
+	}
+	return seen
+}
+
+// createsCycle reports whether making parentID the parent of id would put id
+// among its own ancestors.
+func (t categoryTree) createsCycle(id, parentID int32) bool {
+	return t.descendants(id)[parentID]
+}
+
+// subjectsInCategory returns the ids of the subjects tagged with the category
+// or any of its descendants.
+func (c *Config) subjectsInCategory(ctx context.Context, tree categoryTree, categoryID int32) (map[int32]bool, error) {
+	links, err := c.DB.ListSubjectCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	within := tree.descendants(categoryID)
+	subjects := map[int32]bool{}
+	for _, link := range links {
+		if within[link.CategoryID] {
+			subjects[link.SubjectID] = true
+		}
+	}
+	return subjects, nil
+}
+
+// --- Handler for /categories (List, Create) ---
+func (c *Config) CategoriesHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		c.listCategories(w, r)
+	case http.MethodPost:
+		c.createCategory(w, r)
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// --- Handler for /categories/{id} (Get, Update, Delete) ---
+func (c *Config) CategoryByIDHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		c.getCategoryById(w, r, int32(id))
+	case http.MethodPut:
+		c.updateCategoryById(w, r, int32(id))
+	case http.MethodDelete:
+		c.deleteCategoryById(w, r, int32(id))
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// --- Handler for /categories/tree (hierarchy with rolled up counts) ---
+func (c *Config) CategoryTreeHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	c.getCategoryTree(w, r)
+}
+
+func (c *Config) listCategories(w http.ResponseWriter, r *http.Request) {
+	cats, err := c.DB.GetAllCategories(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error getting categories: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, cats)
+}
+
+func (c *Config) createCategory(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Name     string `json:"name"`
+		ParentID *int32 `json:"parent_id"`
+	}
+	defer r.Body.Close()
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, fmt.Sprintf("Error decoding JSON: %v", err), http.StatusBadRequest)
+		return
+	}
+	if body.Name == "" {
+		http.Error(w, "name is required", http.StatusBadRequest)
+		return
+	}
+	parent := sql.NullInt32{}
+	if body.ParentID != nil {
+		if _, err := c.DB.GetCategoryByID(r.Context(), *body.ParentID); err != nil {
+			http.Error(w, fmt.Sprintf("Unknown parent category %d", *body.ParentID), http.StatusBadRequest)
+			return
+		}
+		parent = sql.NullInt32{Int32: *body.ParentID, Valid: true}
+	}
+	result, err := c.DB.CreateCategoryWithParent(r.Context(), database.CreateCategoryWithParentParams{
+		Name:     body.Name,
+		ParentID: parent,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			http.Error(w, "name already exists", http.StatusConflict)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Error creating category: %v", err), http.StatusInternalServerError)
+		return
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		http.Error(w, "Error retrieving last insert ID", http.StatusInternalServerError)
+		return
+	}
+	cat, err := c.DB.GetCategoryByID(r.Context(), int32(id))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error creating category: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, cat)
+}
+
+func (c *Config) getCategoryById(w http.ResponseWriter, r *http.Request, id int32) {
+	cat, err := c.DB.GetCategoryByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, cat)
+}
+
+// optionalID tells a field left out of a body apart from an explicit null.
+type optionalID struct {
+	Set bool
+	ID  *int32
+}
+
+func (o *optionalID) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	return json.Unmarshal(data, &o.ID)
+}
+
+// updateCategoryById handles PUT requests to /categories/{id}. Fields left
+// out keep their value; "parent_id": null moves the category to the top
+// level. Moving a category below itself or one of its descendants is
+// rejected.
+func (c *Config) updateCategoryById(w http.ResponseWriter, r *http.Request, id int32) {
+	cat, err := c.DB.GetCategoryByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	var body struct {
+		Name     string     `json:"name"`
+		ParentID optionalID `json:"parent_id"`
+	}
+	defer r.Body.Close()
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, fmt.Sprintf("Error decoding JSON: %v", err), http.StatusBadRequest)
+		return
+	}
+	if body.Name == "" {
+		body.Name = cat.Name
+	}
+	parent := cat.ParentID
+	if body.ParentID.Set {
+		parent = sql.NullInt32{}
+	}
+	if body.ParentID.ID != nil {
+		parentID := *body.ParentID.ID
+		tree, err := c.loadCategoryTree(r.Context())
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Error loading categories: %v", err), http.StatusInternalServerError)
+			return
+		}
+		if _, ok := tree.byID[parentID]; !ok {
+			http.Error(w, fmt.Sprintf("Unknown parent category %d", parentID), http.StatusBadRequest)
+			return
+		}
+		if tree.createsCycle(id, parentID) {
+			http.Error(w, "A category cannot be placed under itself or its descendants", http.StatusBadRequest)
+			return
+		}
+		parent = sql.NullInt32{Int32: parentID, Valid: true}
+	}
+	err = c.inTx(r.Context(), func(q *database.Queries) error {
+		if _, err := q.UpdateCategory(r.Context(), database.UpdateCategoryParams{Name: body.Name, ID: id}); err != nil {
+			return err
+		}
+		return q.UpdateCategoryParent(r.Context(), database.UpdateCategoryParentParams{ParentID: parent, ID: id})
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			http.Error(w, "name already exists", http.StatusConflict)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Error updating category: %v", err), http.StatusInternalServerError)
+		return
+	}
+	c.getCategoryById(w, r, id)
+}
+
+// deleteCategoryById handles DELETE requests to /categories/{id}. Subjects
+// lose the category and its children move up to the top level.
+func (c *Config) deleteCategoryById(w http.ResponseWriter, r *http.Request, id int32) {
+	if _, err := c.DB.DeleteCategory(r.Context(), id); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to delete category: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getCategoryTree handles GET requests to /categories/tree. Every node counts
+// the subjects tagged directly with it and, rolled up through its
+// descendants, the distinct subjects below it and how many of them are
+// completed. With ?student_id= the completions are that student's passed
+// ones; otherwise they count passed completions of all students, narrowed by
+// ?term_id= and ?cohort_id=.
+func (c *Config) getCategoryTree(w http.ResponseWriter, r *http.Request) {
+	tree, err := c.loadCategoryTree(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error loading categories: %v", err), http.StatusInternalServerError)
+		return
+	}
+	links, err := c.DB.ListSubjectCategories(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error loading subject categories: %v", err), http.StatusInternalServerError)
+		return
+	}
+	var completions []database.Studentsubjectcompletion
+	if r.URL.Query().Has("student_id") {
+		studentID, err := strconv.Atoi(r.URL.Query().Get("student_id"))
+		if err != nil {
+			http.Error(w, "Invalid student_id", http.StatusBadRequest)
+			return
+		}
+		completions, err = c.DB.ListStudentSubjectCompletionsByStudent(r.Context(), int32(studentID))
+	} else {
+		completions, err = c.DB.ListStudentSubjectCompletions(r.Context())
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error loading completions: %v", err), http.StatusInternalServerError)
+		return
+	}
+	sc, err := c.scopeFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	completed := map[int32]int{}
+	for _, comp := range completions {
+		if comp.Passed && sc.includesCompletion(comp) {
+			completed[comp.SubjectID]++
+		}
+	}
+	direct := map[int32][]int32{}
+	for _, link := range links {
+		direct[link.CategoryID] = append(direct[link.CategoryID], link.SubjectID)
+	}
+
+	// build returns the node together with the distinct subjects below it so
+	// a subject tagged at several levels is only counted once
+	var build func(id int32) (*categoryNode, map[int32]bool)
+	build = func(id int32) (*categoryNode, map[int32]bool) {
+		cat := tree.byID[id]
+		node := &categoryNode{ID: cat.ID, Name: cat.Name, Subjects: len(direct[id]), Children: []*categoryNode{}}
+		if cat.ParentID.Valid {
+			node.ParentID = &cat.ParentID.Int32
+		}
+		subjects := map[int32]bool{}
+		for _, sub := range direct[id] {
+			subjects[sub] = true
+		}
+		for _, child := range tree.children[id] {
+			childNode, childSubjects := build(child)
+			node.Children = append(node.Children, childNode)
+			for sub := range childSubjects {
+				subjects[sub] = true
+			}
+		}
+		node.TotalSubjects = len(subjects)
+		for sub := range subjects {
+			node.Completed += completed[sub]
+		}
+		return node, subjects
+	}
+	roots := []*categoryNode{}
+	for _, id := range tree.roots {
+		node, _ := build(id)
+		roots = append(roots, node)
+	}
+	respondWithJSON(w, http.StatusOK, roots)
+}
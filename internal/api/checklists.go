@@ -0,0 +1,387 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wilgnert/webtutoria/internal/database"
+)
+
+type subjectChecklist struct {
+	SubjectID    int32                    `json:"subject_id"`
+	AutoComplete bool                     `json:"auto_complete"`
+	Items        []database.Checklistitem `json:"items"`
+}
+
+type studentChecklistProgress struct {
+	SubjectID       int32                                       `json:"subject_id"`
+	RequiredTotal   int                                         `json:"required_total"`
+	RequiredChecked int                                         `json:"required_checked"`
+	Completed       bool                                        `json:"completed"`
+	Items           []database.ListStudentChecklistBySubjectRow `json:"items"`
+}
+
+// --- Handler for /subjects/{id}/checklist (Get, Replace) ---
+func (c *Config) SubjectChecklistHandler(w http.ResponseWriter, r *http.Request) {
+	id_str := r.PathValue("id")
+	id, err := strconv.Atoi(id_str)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		c.getSubjectChecklist(w, r, int32(id))
+	case http.MethodPut:
+		c.replaceSubjectChecklist(w, r, int32(id))
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// --- Handler for /students/{id}/checklist (per-subject progress) ---
+func (c *Config) StudentChecklistHandler(w http.ResponseWriter, r *http.Request) {
+	id_str := r.PathValue("id")
+	id, err := strconv.Atoi(id_str)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	c.listStudentChecklistProgress(w, r, int32(id))
+}
+
+// --- Handler for /students/{id}/checklist/{item_id} (Check, Uncheck) ---
+func (c *Config) StudentChecklistItemHandler(w http.ResponseWriter, r *http.Request) {
+	studentID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	itemID, err := strconv.Atoi(r.PathValue("item_id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodPut:
+		c.checkStudentChecklistItem(w, r, int32(studentID), int32(itemID))
+	case http.MethodDelete:
+		c.uncheckStudentChecklistItem(w, r, int32(studentID), int32(itemID))
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (c *Config) getSubjectChecklist(w http.ResponseWriter, r *http.Request, id int32) {
+	sub, err := c.DB.GetSubjectByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	items, err := c.DB.ListChecklistItemsBySubject(r.Context(), id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list checklist items: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, subjectChecklist{
+		SubjectID:    sub.ID,
+		AutoComplete: sub.AutoComplete,
+		Items:        items,
+	})
+}
+
+// replaceSubjectChecklist handles PUT requests to /subjects/{id}/checklist.
+// Items are matched by label so students keep their ticks on items that
+// survive the edit.
+func (c *Config) replaceSubjectChecklist(w http.ResponseWriter, r *http.Request, id int32) {
+	if _, err := c.DB.GetSubjectByID(r.Context(), id); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	var body struct {
+		AutoComplete bool `json:"auto_complete"`
+		Items        []struct {
+			Label    string `json:"label"`
+			Required *bool  `json:"required"`
+		} `json:"items"`
+	}
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, "Invalid body", http.StatusBadRequest)
+		return
+	}
+	keep := map[string]bool{}
+	for _, item := range body.Items {
+		if item.Label == "" {
+			http.Error(w, "checklist items need a label", http.StatusBadRequest)
+			return
+		}
+		if keep[item.Label] {
+			http.Error(w, fmt.Sprintf("duplicate checklist item %q", item.Label), http.StatusBadRequest)
+			return
+		}
+		keep[item.Label] = true
+	}
+
+	// the checklist is replaced as a whole or not at all
+	err := c.inTx(r.Context(), func(q *database.Queries) error {
+		err := q.UpdateSubjectAutoComplete(r.Context(), database.UpdateSubjectAutoCompleteParams{
+			AutoComplete: body.AutoComplete,
+			ID:           id,
+		})
+		if err != nil {
+			return err
+		}
+		for i, item := range body.Items {
+			// Items are required unless stated otherwise
+			required := item.Required == nil || *item.Required
+			err := q.UpsertChecklistItem(r.Context(), database.UpsertChecklistItemParams{
+				SubjectID: id,
+				Label:     item.Label,
+				Required:  required,
+				Position:  int32(i),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		existing, err := q.ListChecklistItemsBySubject(r.Context(), id)
+		if err != nil {
+			return err
+		}
+		for _, item := range existing {
+			if keep[item.Label] {
+				continue
+			}
+			if err := q.DeleteChecklistItem(r.Context(), item.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to save checklist: %v", err), http.StatusInternalServerError)
+		return
+	}
+	c.getSubjectChecklist(w, r, id)
+}
+
+// listStudentChecklistProgress handles GET requests to /students/{id}/checklist.
+// Without ?subject_id= it reports every subject the student has ticked at
+// least one item of.
+func (c *Config) listStudentChecklistProgress(w http.ResponseWriter, r *http.Request, studentID int32) {
+	if _, err := c.DB.GetStudentByID(r.Context(), studentID); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	var subjectIDs []int32
+	if subjectIDStr := r.URL.Query().Get("subject_id"); subjectIDStr != "" {
+		subjectID, err := strconv.ParseInt(subjectIDStr, 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid subject_id format", http.StatusBadRequest)
+			return
+		}
+		subjectIDs = []int32{int32(subjectID)}
+	} else {
+		var err error
+		subjectIDs, err = c.DB.ListChecklistSubjectsByStudent(r.Context(), studentID)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to list checklist subjects: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+	progress := []studentChecklistProgress{}
+	for _, subjectID := range subjectIDs {
+		p, err := c.checklistProgress(r.Context(), studentID, subjectID)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to get checklist progress: %v", err), http.StatusInternalServerError)
+			return
+		}
+		progress = append(progress, p)
+	}
+	respondWithJSON(w, http.StatusOK, progress)
+}
+
+func (c *Config) checkStudentChecklistItem(w http.ResponseWriter, r *http.Request, studentID, itemID int32) {
+	if _, err := c.DB.GetStudentByID(r.Context(), studentID); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	item, err := c.DB.GetChecklistItemByID(r.Context(), itemID)
+	if err != nil {
+		http.Error(w, "Checklist item not found", http.StatusNotFound)
+		return
+	}
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := c.checkActsOn(r.Context(), by, studentID); err != nil {
+		actorError(w, err)
+		return
+	}
+	err = c.DB.CheckStudentChecklistItem(r.Context(), database.CheckStudentChecklistItemParams{
+		StudentID:       studentID,
+		ChecklistItemID: itemID,
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to check checklist item: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if err := c.startSubject(r.Context(), studentID, item.SubjectID); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to set subject status: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if err := c.autoCompleteSubject(r.Context(), studentID, item.SubjectID, by); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to auto complete subject: %v", err), http.StatusInternalServerError)
+		return
+	}
+	p, err := c.checklistProgress(r.Context(), studentID, item.SubjectID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get checklist progress: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, p)
+}
+
+func (c *Config) uncheckStudentChecklistItem(w http.ResponseWriter, r *http.Request, studentID, itemID int32) {
+	if _, err := c.DB.GetStudentByID(r.Context(), studentID); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	item, err := c.DB.GetChecklistItemByID(r.Context(), itemID)
+	if err != nil {
+		http.Error(w, "Checklist item not found", http.StatusNotFound)
+		return
+	}
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := c.checkActsOn(r.Context(), by, studentID); err != nil {
+		actorError(w, err)
+		return
+	}
+	err = c.DB.UncheckStudentChecklistItem(r.Context(), database.UncheckStudentChecklistItemParams{
+		StudentID:       studentID,
+		ChecklistItemID: itemID,
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to uncheck checklist item: %v", err), http.StatusInternalServerError)
+		return
+	}
+	p, err := c.checklistProgress(r.Context(), studentID, item.SubjectID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get checklist progress: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, p)
+}
+
+func (c *Config) checklistProgress(ctx context.Context, studentID, subjectID int32) (studentChecklistProgress, error) {
+	items, err := c.DB.ListStudentChecklistBySubject(ctx, database.ListStudentChecklistBySubjectParams{
+		StudentID: studentID,
+		SubjectID: subjectID,
+	})
+	if err != nil {
+		return studentChecklistProgress{}, err
+	}
+	p := studentChecklistProgress{SubjectID: subjectID, Items: items}
+	for _, item := range items {
+		if !item.Required {
+			continue
+		}
+		p.RequiredTotal++
+		if item.CheckedAt.Valid {
+			p.RequiredChecked++
+		}
+	}
+	_, err = c.DB.GetStudentSubjectCompletionByStudentAndSubject(ctx, database.GetStudentSubjectCompletionByStudentAndSubjectParams{
+		StudentID: studentID,
+		SubjectID: subjectID,
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return studentChecklistProgress{}, err
+	}
+	p.Completed = err == nil
+	return p, nil
+}
+
+// autoCompleteSubject records a completion once every required item of an
+// auto_complete subject has been ticked. It does nothing otherwise, nor for
+// subjects graded by rubric, which a tutor has to score.
+func (c *Config) autoCompleteSubject(ctx context.Context, studentID, subjectID int32, by actor) error {
+	sub, err := c.DB.GetSubjectByID(ctx, subjectID)
+	if err != nil {
+		return err
+	}
+	if !sub.AutoComplete {
+		return nil
+	}
+	unchecked, err := c.DB.CountUncheckedRequiredItems(ctx, database.CountUncheckedRequiredItemsParams{
+		StudentID: studentID,
+		SubjectID: subjectID,
+	})
+	if err != nil || unchecked > 0 {
+		return err
+	}
+	_, err = c.DB.GetStudentSubjectCompletionByStudentAndSubject(ctx, database.GetStudentSubjectCompletionByStudentAndSubjectParams{
+		StudentID: studentID,
+		SubjectID: subjectID,
+	})
+	if err != sql.ErrNoRows {
+		return err
+	}
+	items, err := c.DB.ListRubricItemsBySubject(ctx, subjectID)
+	if err != nil {
+		return err
+	}
+	grade, passed, err := completionGrade(sub, items, nil)
+	if err == errScoresRequired {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	now := time.Now()
+	revision, err := c.subjectRevisionAt(ctx, subjectID, now)
+	if err != nil {
+		return err
+	}
+	term, err := c.termFor(ctx, nil, now)
+	if err != nil {
+		return err
+	}
+	var id int32
+	err = c.inTx(ctx, func(q *database.Queries) error {
+		id, err = recordCompletion(ctx, q, database.CreateStudentSubjectCompletionParams{
+			StudentID:         studentID,
+			SubjectID:         subjectID,
+			CompletedAt:       sql.NullTime{Time: now, Valid: true},
+			Grade:             grade,
+			Passed:            passed,
+			RecordedByTutorID: by.TutorID,
+			RecordedBy:        by.Name,
+			RevisionID:        revision,
+			TermID:            term,
+		}, nil)
+		return err
+	})
+	if err != nil && strings.Contains(err.Error(), "Duplicate entry") {
+		// a concurrent tick completed the subject first
+		return nil
+	} else if err != nil {
+		return err
+	}
+	c.completionRecorded(ctx, studentID, id, by)
+	return nil
+}
@@ -0,0 +1,221 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/wilgnert/webtutoria/internal/database"
+)
+
+func (c *Config) ClassesHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		c.listClasses(w, r)
+	case http.MethodPost:
+		c.createClass(w, r)
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (c *Config) ClassByIDHandler(w http.ResponseWriter, r *http.Request) {
+	id_str := r.PathValue("id")
+	id, err := strconv.Atoi(id_str)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		c.getClassById(w, r, int32(id))
+	case http.MethodPut:
+		c.updateClassById(w, r, int32(id))
+	case http.MethodDelete:
+		c.deleteClassById(w, r, int32(id))
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// normalizeClassCode trims and upper-cases a class code so that "f" and "F "
+// refer to the same class.
+func normalizeClassCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// lookupClass returns the class a subject is filed under, writing a 400 for
+// an empty or unknown code. Classes are only created through POST /classes.
+func (c *Config) lookupClass(w http.ResponseWriter, r *http.Request, code string) (database.Class, bool) {
+	code = normalizeClassCode(code)
+	if code == "" {
+		http.Error(w, "class is required", http.StatusBadRequest)
+		return database.Class{}, false
+	}
+	class, err := c.DB.GetClassByCode(r.Context(), code)
+	if err == sql.ErrNoRows {
+		http.Error(w, fmt.Sprintf("Unknown class %q", code), http.StatusBadRequest)
+		return database.Class{}, false
+	} else if err != nil {
+		http.Error(w, fmt.Sprintf("Internal server error while getting class: %v", err), http.StatusInternalServerError)
+		return database.Class{}, false
+	}
+	return class, true
+}
+
+// classConflict writes a 409 when a class write hits an existing code or
+// position. Positions are unique so the ladder always has a single next class.
+func classConflict(w http.ResponseWriter, err error, position int32) bool {
+	if !strings.Contains(err.Error(), "Duplicate entry") {
+		return false
+	}
+	if strings.Contains(err.Error(), "uq_class_position") {
+		http.Error(w, fmt.Sprintf("another class already has position %d", position), http.StatusConflict)
+	} else {
+		http.Error(w, "code already exists", http.StatusConflict)
+	}
+	return true
+}
+
+func (c *Config) listClasses(w http.ResponseWriter, r *http.Request) {
+	classes, err := c.DB.ListClasses(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error getting classes: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, 200, classes)
+}
+
+func (c *Config) createClass(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Code        string `json:"code"`
+		Name        string `json:"name"`
+		Position    *int32 `json:"position"`
+		Description string `json:"description"`
+	}
+	defer r.Body.Close()
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, fmt.Sprintf("Error decoding JSON: %v", err), http.StatusBadRequest)
+		return
+	}
+	code := normalizeClassCode(body.Code)
+	if code == "" {
+		http.Error(w, "code is required", http.StatusBadRequest)
+		return
+	}
+	if body.Name == "" {
+		body.Name = code
+	}
+	// classes without a position go at the end of the ladder
+	if body.Position == nil {
+		last, err := c.DB.GetLastClassPosition(r.Context())
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Error getting class positions: %v", err), http.StatusInternalServerError)
+			return
+		}
+		next := int32(last) + 1
+		body.Position = &next
+	}
+	result, err := c.DB.CreateClass(r.Context(), database.CreateClassParams{
+		Code:     code,
+		Name:     body.Name,
+		Position: *body.Position,
+		Description: sql.NullString{
+			String: body.Description,
+			Valid:  len(body.Description) > 0,
+		},
+	})
+	if err != nil {
+		if classConflict(w, err, *body.Position) {
+			return
+		}
+		http.Error(w, fmt.Sprintf("Error creating class: %v", err), http.StatusInternalServerError)
+		return
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		http.Error(w, "Error retrieving last insert ID", http.StatusInternalServerError)
+		return
+	}
+	class, err := c.DB.GetClassByID(r.Context(), int32(id))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error creating class: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, class)
+}
+
+func (c *Config) getClassById(w http.ResponseWriter, r *http.Request, id int32) {
+	class, err := c.DB.GetClassByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	respondWithJSON(w, 200, class)
+}
+
+// updateClassById handles PUT requests to /classes/{id}. Changing the code
+// also renames the class on its subjects through the foreign key.
+func (c *Config) updateClassById(w http.ResponseWriter, r *http.Request, id int32) {
+	class, err := c.DB.GetClassByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	var body struct {
+		Code        string `json:"code"`
+		Name        string `json:"name"`
+		Position    *int32 `json:"position"`
+		Description string `json:"description"`
+	}
+	defer r.Body.Close()
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, fmt.Sprintf("Error decoding JSON: %v", err), http.StatusBadRequest)
+		return
+	}
+	code := normalizeClassCode(body.Code)
+	if code == "" {
+		http.Error(w, "code is required", http.StatusBadRequest)
+		return
+	}
+	if body.Name == "" {
+		body.Name = code
+	}
+	// the class keeps its place unless the body moves it
+	if body.Position == nil {
+		body.Position = &class.Position
+	}
+	_, err = c.DB.UpdateClass(r.Context(), database.UpdateClassParams{
+		Code:     code,
+		Name:     body.Name,
+		Position: *body.Position,
+		Description: sql.NullString{
+			String: body.Description,
+			Valid:  len(body.Description) > 0,
+		},
+		ID: id,
+	})
+	if err != nil {
+		if classConflict(w, err, *body.Position) {
+			return
+		}
+		http.Error(w, fmt.Sprintf("Error updating class: %v", err), http.StatusInternalServerError)
+		return
+	}
+	c.getClassById(w, r, id)
+}
+
+func (c *Config) deleteClassById(w http.ResponseWriter, r *http.Request, id int32) {
+	err := c.DB.DeleteClass(r.Context(), id)
+	if err != nil {
+		if strings.Contains(err.Error(), "foreign key constraint fails") {
+			http.Error(w, "Class still has subjects", http.StatusConflict)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to delete class: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -14,8 +14,32 @@ import (
 
 type Config struct {
 	DB *database.Queries
+	// Conn is the pool behind DB, for work that needs a transaction
+	Conn *sql.DB
+	// How far back a completion may be dated, read from
+	// "completion_backdate_days" in config.json
+	BackdateWindow time.Duration
+	// How long a tutor request stays pending, read from
+	// "tutor_request_expiry_days" in config.json
+	TutorRequestTTL time.Duration
+	// How long a help request may wait before it breaks the SLA, read from
+	// "help_sla_minutes" in config.json
+	HelpSLA time.Duration
+	// Thresholds for at-risk scoring, read from the "at_risk" object in
+	// config.json
+	AtRisk RiskThresholds
+	// How often the at-risk scoring job runs, read from
+	// "risk_scoring_interval_hours" in config.json
+	RiskScoringInterval time.Duration
 }
 
+const (
+	defaultBackdateWindow      = 90 * 24 * time.Hour
+	defaultTutorRequestTTL     = 7 * 24 * time.Hour
+	defaultHelpSLA             = 15 * time.Minute
+	defaultRiskScoringInterval = 24 * time.Hour
+)
+
 func respondWithJSON(w http.ResponseWriter, code int, payload interface{}) error {
 	res, err := json.Marshal(payload)
 	if err != nil {
@@ -61,6 +85,33 @@ func (c *Config) Init() error {
 		return fmt.Errorf("error unmarshalling JSON: %w", err)
 	}
 
+	c.BackdateWindow = defaultBackdateWindow
+	if days, ok := result["completion_backdate_days"].(float64); ok {
+		c.BackdateWindow = time.Duration(days * float64(24*time.Hour))
+	}
+	c.TutorRequestTTL = defaultTutorRequestTTL
+	if days, ok := result["tutor_request_expiry_days"].(float64); ok {
+		if days <= 0 {
+			return fmt.Errorf("tutor_request_expiry_days must be positive, got %v", days)
+		}
+		c.TutorRequestTTL = time.Duration(days * float64(24*time.Hour))
+	}
+	c.HelpSLA = defaultHelpSLA
+	if minutes, ok := result["help_sla_minutes"].(float64); ok {
+		c.HelpSLA = time.Duration(minutes * float64(time.Minute))
+	}
+	c.AtRisk = defaultRiskThresholds
+	if section, ok := result["at_risk"].(map[string]any); ok {
+		c.AtRisk.read(section)
+	}
+	if err := c.AtRisk.validate(); err != nil {
+		return fmt.Errorf("invalid at_risk thresholds: %w", err)
+	}
+	c.RiskScoringInterval = defaultRiskScoringInterval
+	if hours, ok := result["risk_scoring_interval_hours"].(float64); ok && hours > 0 {
+		c.RiskScoringInterval = time.Duration(hours * float64(time.Hour))
+	}
+
 	db_url := result["db_url"].(string)
 	db, err := sql.Open("mysql", db_url)
 	if err != nil {
@@ -71,13 +122,15 @@ func (c *Config) Init() error {
 		return fmt.Errorf("error pinging database: %w", err)
 	}
 	c.DB = database.New(db)
+	c.Conn = db
 	time.Sleep(1 * time.Second)
 
 	return nil
 }
 
 func (c *Config) ResetHandler(w http.ResponseWriter, r *http.Request) {
-	// reset categories, students, tutors and subjects
+	// reset categories, students, tutors, subjects, classes, terms, events,
+	// badges and learning paths
 	err := c.DB.ResetCategories(r.Context())
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Failed to reset categories: %v", err), http.StatusInternalServerError)
@@ -98,5 +151,30 @@ func (c *Config) ResetHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, fmt.Sprintf("Failed to reset subjects: %v", err), http.StatusInternalServerError)
 		return
 	}
+	err = c.DB.ResetClasses(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to reset classes: %v", err), http.StatusInternalServerError)
+		return
+	}
+	err = c.DB.ResetTerms(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to reset terms: %v", err), http.StatusInternalServerError)
+		return
+	}
+	err = c.DB.ResetEvents(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to reset events: %v", err), http.StatusInternalServerError)
+		return
+	}
+	err = c.DB.ResetBadges(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to reset badges: %v", err), http.StatusInternalServerError)
+		return
+	}
+	err = c.DB.ResetLearningPaths(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to reset learning paths: %v", err), http.StatusInternalServerError)
+		return
+	}
 	respondWithJSON(w, http.StatusOK, map[string]string{"status": "ok"})
 }
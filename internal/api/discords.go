@@ -54,9 +54,13 @@ func (c *Config) StudentDiscordByIDHandler(w http.ResponseWriter, r *http.Reques
 func (c *Config) listStudentDiscords(w http.ResponseWriter, r *http.Request) {
 	// Optional: Allow filtering by discord_id as a query parameter
 	discordIDQuery := r.URL.Query().Get("discord_id")
+	sc, err := c.scopeFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	var studentDiscords []database.Studentdiscord
-	var err error
 
 	if discordIDQuery != "" {
 		temp, err := c.DB.GetStudentDiscordByDiscordID(r.Context(), discordIDQuery)
@@ -75,9 +79,31 @@ func (c *Config) listStudentDiscords(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, fmt.Sprintf("Failed to list student discords: %v", err), http.StatusInternalServerError)
 			return
 		}
+		// The full list drives Discord role sync, which skips paused and
+		// dropped students unless ?include_inactive=true
+		if r.URL.Query().Get("include_inactive") != "true" {
+			engaged, err := c.engagedStudents(r.Context())
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Failed to list students: %v", err), http.StatusInternalServerError)
+				return
+			}
+			filtered := []database.Studentdiscord{}
+			for _, sd := range studentDiscords {
+				if engaged[sd.StudentID] {
+					filtered = append(filtered, sd)
+				}
+			}
+			studentDiscords = filtered
+		}
 	}
 
-	respondWithJSON(w, http.StatusOK, studentDiscords)
+	scoped := []database.Studentdiscord{}
+	for _, sd := range studentDiscords {
+		if sc.hasStudent(sd.StudentID) {
+			scoped = append(scoped, sd)
+		}
+	}
+	respondWithJSON(w, http.StatusOK, scoped)
 }
 
 // createStudentDiscord handles POST requests to /student-discords.
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/wilgnert/webtutoria/internal/database"
+)
+
+const (
+	eventStudentClassChanged = "student.class_changed"
+)
+
+type eventView struct {
+	ID        int32           `json:"id"`
+	Kind      string          `json:"kind"`
+	StudentID *int32          `json:"student_id"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func toEventView(e database.Event) eventView {
+	view := eventView{ID: e.ID, Kind: e.Kind, Payload: json.RawMessage(e.Payload), CreatedAt: e.CreatedAt}
+	if e.StudentID.Valid {
+		view.StudentID = &e.StudentID.Int32
+	}
+	return view
+}
+
+// emitEvent appends an event to the outbox. Clients poll GET /events with
+// the id of the last event they saw.
+func (c *Config) emitEvent(ctx context.Context, kind string, studentID int32, payload any) error {
+	return emitEventWith(ctx, c.DB, kind, studentID, payload)
+}
+
+// emitEventWith appends the event through q, so it commits or rolls back with
+// the change it announces.
+func emitEventWith(ctx context.Context, q *database.Queries, kind string, studentID int32, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	_, err = q.CreateEvent(ctx, database.CreateEventParams{
+		Kind:      kind,
+		StudentID: sql.NullInt32{Int32: studentID, Valid: studentID != 0},
+		Payload:   string(data),
+	})
+	return err
+}
+
+// EventsHandler handles GET requests to /events?after={id}&kind={kind}&limit={n}.
+func (c *Config) EventsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	after, limit := int64(0), int64(100)
+	var err error
+	if s := r.URL.Query().Get("after"); s != "" {
+		after, err = strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid after format", http.StatusBadRequest)
+			return
+		}
+	}
+	if s := r.URL.Query().Get("limit"); s != "" {
+		limit, err = strconv.ParseInt(s, 10, 32)
+		if err != nil || limit <= 0 || limit > 500 {
+			http.Error(w, "limit must be between 1 and 500", http.StatusBadRequest)
+			return
+		}
+	}
+
+	var events []database.Event
+	if kind := r.URL.Query().Get("kind"); kind != "" {
+		events, err = c.DB.ListEventsByKindAfter(r.Context(), database.ListEventsByKindAfterParams{
+			Kind:  kind,
+			ID:    int32(after),
+			Limit: int32(limit),
+		})
+	} else {
+		events, err = c.DB.ListEventsAfter(r.Context(), database.ListEventsAfterParams{
+			ID:    int32(after),
+			Limit: int32(limit),
+		})
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list events: %v", err), http.StatusInternalServerError)
+		return
+	}
+	views := []eventView{}
+	for _, e := range events {
+		views = append(views, toEventView(e))
+	}
+	respondWithJSON(w, http.StatusOK, views)
+}
@@ -0,0 +1,429 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/wilgnert/webtutoria/internal/database"
+)
+
+const (
+	goalAchieved = "achieved"
+	goalOnTrack  = "on_track"
+	goalAtRisk   = "at_risk"
+	goalOverdue  = "overdue"
+
+	// how far progress may lag behind the elapsed share of a goal's window
+	// before the goal is at risk
+	goalSlack = 0.25
+)
+
+type goalView struct {
+	ID           int32      `json:"id"`
+	StudentID    int32      `json:"student_id"`
+	SubjectID    *int32     `json:"subject_id"`
+	Class        *string    `json:"class"`
+	TargetDate   time.Time  `json:"target_date"`
+	SetByTutorID *int32     `json:"set_by_tutor_id"`
+	SetBy        string     `json:"set_by"`
+	CreatedAt    time.Time  `json:"created_at"`
+	Status       string     `json:"status"`
+	Passed       int        `json:"passed"`
+	Total        int        `json:"total"`
+	Progress     float64    `json:"progress"`
+	Expected     float64    `json:"expected"`
+	DaysLeft     int        `json:"days_left"`
+	AchievedAt   *time.Time `json:"achieved_at"`
+}
+
+// studentProgress is what goals are measured against: when each subject was
+// passed and which ones are under way.
+type studentProgress struct {
+	passed  map[int32]time.Time
+	started map[int32]bool
+}
+
+func (c *Config) loadStudentProgress(ctx context.Context, studentID int32) (studentProgress, error) {
+	p := studentProgress{passed: map[int32]time.Time{}, started: map[int32]bool{}}
+	completions, err := c.DB.ListStudentSubjectCompletionsByStudent(ctx, studentID)
+	if err != nil {
+		return p, err
+	}
+	for _, comp := range completions {
+		if comp.Passed {
+			p.passed[comp.SubjectID] = comp.CompletedAt.Time
+		}
+	}
+	statuses, err := c.DB.ListStudentSubjectStatusesByStudent(ctx, studentID)
+	if err != nil {
+		return p, err
+	}
+	for _, s := range statuses {
+		if s.Status == subjectInProgress || s.Status == subjectBlocked {
+			p.started[s.SubjectID] = true
+		}
+	}
+	return p, nil
+}
+
+// evaluateGoal works out a goal's status. Progress is the share of its
+// subjects passed, with subjects under way counting half; it is compared with
+// the share of the time between setting the goal and the end of its target
+// date that has gone by. A class goal with no subjects cannot be achieved
+// and is only on track or overdue.
+func evaluateGoal(g database.Studentgoal, subjects []database.Subject, p studentProgress, now time.Time) goalView {
+	view := goalView{
+		ID:           g.ID,
+		StudentID:    g.StudentID,
+		SubjectID:    nullInt32Ptr(g.SubjectID),
+		Class:        nullStringPtr(g.Class),
+		TargetDate:   g.TargetDate,
+		SetByTutorID: nullInt32Ptr(g.SetByTutorID),
+		SetBy:        g.SetBy,
+		CreatedAt:    g.CreatedAt,
+	}
+	var started int
+	var achievedAt time.Time
+	for _, sub := range subjects {
+		if g.SubjectID.Valid && sub.ID != g.SubjectID.Int32 {
+			continue
+		}
+		if !g.SubjectID.Valid && sub.Class != g.Class.String {
+			continue
+		}
+		view.Total++
+		if at, ok := p.passed[sub.ID]; ok {
+			view.Passed++
+			if at.After(achievedAt) {
+				achievedAt = at
+			}
+		} else if p.started[sub.ID] {
+			started++
+		}
+	}
+	end := g.TargetDate.AddDate(0, 0, 1)
+	view.DaysLeft = int(math.Ceil(end.Sub(now).Hours() / 24))
+	if view.Total > 0 {
+		view.Progress = (float64(view.Passed) + float64(started)/2) / float64(view.Total)
+	}
+	if window := end.Sub(g.CreatedAt); window > 0 {
+		view.Expected = math.Min(math.Max(float64(now.Sub(g.CreatedAt))/float64(window), 0), 1)
+	} else {
+		view.Expected = 1
+	}
+	switch {
+	case view.Total > 0 && view.Passed == view.Total:
+		view.Status = goalAchieved
+		view.AchievedAt = &achievedAt
+	case !now.Before(end):
+		view.Status = goalOverdue
+	case view.Total > 0 && view.Progress+goalSlack < view.Expected:
+		view.Status = goalAtRisk
+	default:
+		view.Status = goalOnTrack
+	}
+	return view
+}
+
+func (c *Config) studentGoalViews(ctx context.Context, studentID int32, subjects []database.Subject, now time.Time) ([]goalView, error) {
+	goals, err := c.DB.ListStudentGoals(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	views := []goalView{}
+	if len(goals) == 0 {
+		return views, nil
+	}
+	progress, err := c.loadStudentProgress(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range goals {
+		views = append(views, evaluateGoal(g, subjects, progress, now))
+	}
+	return views, nil
+}
+
+// --- Handler for /students/{id}/goals (List, Create) ---
+func (c *Config) StudentGoalsHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		c.listStudentGoals(w, r, int32(id))
+	case http.MethodPost:
+		c.createStudentGoal(w, r, int32(id))
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// --- Handler for /students/{id}/goals/{goal_id} (Update, Delete) ---
+func (c *Config) StudentGoalByIDHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	goalID, err := strconv.Atoi(r.PathValue("goal_id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodPut:
+		c.updateStudentGoal(w, r, int32(id), int32(goalID))
+	case http.MethodDelete:
+		c.deleteStudentGoal(w, r, int32(id), int32(goalID))
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// --- Handler for /tutors/{id}/overdue ---
+func (c *Config) TutorOverdueHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	c.tutorOverdueReport(w, r, int32(id))
+}
+
+func (c *Config) listStudentGoals(w http.ResponseWriter, r *http.Request, studentID int32) {
+	if _, err := c.DB.GetStudentByID(r.Context(), studentID); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	subjects, err := c.DB.ListSubjects(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list subjects: %v", err), http.StatusInternalServerError)
+		return
+	}
+	views, err := c.studentGoalViews(r.Context(), studentID, subjects, time.Now())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to evaluate goals: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, views)
+}
+
+// createStudentGoal handles POST requests to /students/{id}/goals with either
+// a subject_id or a class, and a target_date (YYYY-MM-DD).
+func (c *Config) createStudentGoal(w http.ResponseWriter, r *http.Request, studentID int32) {
+	var body struct {
+		SubjectID  *int32 `json:"subject_id"`
+		Class      string `json:"class"`
+		TargetDate string `json:"target_date"`
+	}
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if (body.SubjectID == nil) == (body.Class == "") {
+		http.Error(w, "A goal needs either a subject_id or a class", http.StatusBadRequest)
+		return
+	}
+	target, err := time.Parse(termDateLayout, body.TargetDate)
+	if err != nil {
+		http.Error(w, "target_date must be YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := c.DB.GetStudentByID(r.Context(), studentID); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if err := c.checkActsOn(r.Context(), by, studentID); err != nil {
+		actorError(w, err)
+		return
+	}
+	subject := sql.NullInt32{}
+	class := sql.NullString{}
+	if body.SubjectID != nil {
+		if _, err := c.DB.GetSubjectByID(r.Context(), *body.SubjectID); err != nil {
+			http.Error(w, "Subject not found", http.StatusBadRequest)
+			return
+		}
+		subject = sql.NullInt32{Int32: *body.SubjectID, Valid: true}
+	} else {
+		if _, err := c.DB.GetClassByCode(r.Context(), body.Class); err != nil {
+			http.Error(w, fmt.Sprintf("Unknown class %q", body.Class), http.StatusBadRequest)
+			return
+		}
+		class = sql.NullString{String: body.Class, Valid: true}
+	}
+	result, err := c.DB.CreateStudentGoal(r.Context(), database.CreateStudentGoalParams{
+		StudentID:    studentID,
+		SubjectID:    subject,
+		Class:        class,
+		TargetDate:   target,
+		SetByTutorID: by.TutorID,
+		SetBy:        by.Name,
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to create goal: %v", err), http.StatusInternalServerError)
+		return
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to retrieve new goal ID: %v", err), http.StatusInternalServerError)
+		return
+	}
+	c.respondWithGoal(w, r, int32(id), http.StatusCreated)
+}
+
+func (c *Config) respondWithGoal(w http.ResponseWriter, r *http.Request, id int32, code int) {
+	goal, err := c.DB.GetStudentGoalByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get goal: %v", err), http.StatusInternalServerError)
+		return
+	}
+	subjects, err := c.DB.ListSubjects(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list subjects: %v", err), http.StatusInternalServerError)
+		return
+	}
+	progress, err := c.loadStudentProgress(r.Context(), goal.StudentID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to evaluate goal: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, code, evaluateGoal(goal, subjects, progress, time.Now()))
+}
+
+// studentGoal loads a goal of the student that the actor may change.
+func (c *Config) studentGoal(w http.ResponseWriter, r *http.Request, studentID, goalID int32) bool {
+	goal, err := c.DB.GetStudentGoalByID(r.Context(), goalID)
+	if err != nil || goal.StudentID != studentID {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return false
+	}
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	if err := c.checkActsOn(r.Context(), by, studentID); err != nil {
+		actorError(w, err)
+		return false
+	}
+	return true
+}
+
+// updateStudentGoal handles PUT requests to /students/{id}/goals/{goal_id},
+// moving the target date.
+func (c *Config) updateStudentGoal(w http.ResponseWriter, r *http.Request, studentID, goalID int32) {
+	var body struct {
+		TargetDate string `json:"target_date"`
+	}
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	target, err := time.Parse(termDateLayout, body.TargetDate)
+	if err != nil {
+		http.Error(w, "target_date must be YYYY-MM-DD", http.StatusBadRequest)
+		return
+	}
+	if !c.studentGoal(w, r, studentID, goalID) {
+		return
+	}
+	err = c.DB.UpdateStudentGoalTarget(r.Context(), database.UpdateStudentGoalTargetParams{
+		TargetDate: target,
+		ID:         goalID,
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to update goal: %v", err), http.StatusInternalServerError)
+		return
+	}
+	c.respondWithGoal(w, r, goalID, http.StatusOK)
+}
+
+func (c *Config) deleteStudentGoal(w http.ResponseWriter, r *http.Request, studentID, goalID int32) {
+	if !c.studentGoal(w, r, studentID, goalID) {
+		return
+	}
+	if err := c.DB.DeleteStudentGoal(r.Context(), goalID); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to delete goal: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// tutorOverdueReport handles GET requests to /tutors/{id}/overdue, listing
+// the overdue goals of the tutor's students, including those they currently
+// cover for an absent tutor. ?include_at_risk=true adds at-risk goals, and
+// ?term_id and ?cohort_id narrow it to the assignments of the scope.
+func (c *Config) tutorOverdueReport(w http.ResponseWriter, r *http.Request, tutorID int32) {
+	if _, err := c.DB.GetTutorByID(r.Context(), tutorID); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	includeAtRisk := r.URL.Query().Get("include_at_risk") == "true"
+	sc, err := c.scopeFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	assignments, err := c.DB.ListStudentTutorsByTutor(r.Context(), tutorID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list tutor students: %v", err), http.StatusInternalServerError)
+		return
+	}
+	covered, err := c.coveredAssignments(r.Context(), tutorID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list covered students: %v", err), http.StatusInternalServerError)
+		return
+	}
+	subjects, err := c.DB.ListSubjects(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list subjects: %v", err), http.StatusInternalServerError)
+		return
+	}
+	type overdueRow struct {
+		StudentName string `json:"student_name"`
+		goalView
+	}
+	now := time.Now()
+	seen := map[int32]bool{}
+	rows := []overdueRow{}
+	for _, st := range append(assignments, covered...) {
+		if st.EndedAt.Valid || seen[st.StudentID] || !sc.hasTerm(st.TermID) || !sc.inCohort(st.StudentID) {
+			continue
+		}
+		seen[st.StudentID] = true
+		stud, err := c.DB.GetStudentByID(r.Context(), st.StudentID)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to get student: %v", err), http.StatusInternalServerError)
+			return
+		}
+		views, err := c.studentGoalViews(r.Context(), st.StudentID, subjects, now)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to evaluate goals: %v", err), http.StatusInternalServerError)
+			return
+		}
+		for _, v := range views {
+			if v.Status == goalOverdue || (includeAtRisk && v.Status == goalAtRisk) {
+				rows = append(rows, overdueRow{StudentName: stud.Name, goalView: v})
+			}
+		}
+	}
+	respondWithJSON(w, http.StatusOK, rows)
+}
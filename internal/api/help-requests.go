@@ -0,0 +1,601 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/wilgnert/webtutoria/internal/database"
+)
+
+var errHelpChanged = errors.New("help request changed in the meantime")
+
+const (
+	helpOpen     = "open"
+	helpClaimed  = "claimed"
+	helpResolved = "resolved"
+
+	maxHelpPriority = 2
+
+	eventHelpOpened   = "help_request.opened"
+	eventHelpClaimed  = "help_request.claimed"
+	eventHelpReleased = "help_request.released"
+	eventHelpResolved = "help_request.resolved"
+
+	helpStreamInterval = time.Second
+	// a comment line keeps idle proxies from closing the stream
+	helpStreamKeepAlive = 15 * time.Second
+)
+
+type helpRequestView struct {
+	ID            int32      `json:"id"`
+	StudentID     int32      `json:"student_id"`
+	SubjectID     int32      `json:"subject_id"`
+	Message       *string    `json:"message"`
+	Priority      int32      `json:"priority"`
+	Status        string     `json:"status"`
+	TutorID       *int32     `json:"tutor_id"`
+	CreatedAt     time.Time  `json:"created_at"`
+	QueuedAt      time.Time  `json:"queued_at"`
+	ClaimedAt     *time.Time `json:"claimed_at"`
+	ResolvedAt    *time.Time `json:"resolved_at"`
+	WaitSeconds   int32      `json:"wait_seconds"`
+	HandleSeconds int32      `json:"handle_seconds"`
+	Handbacks     int32      `json:"handbacks"`
+	Resolution    *string    `json:"resolution"`
+}
+
+func newHelpRequestView(req database.Helprequest) helpRequestView {
+	return helpRequestView{
+		ID:            req.ID,
+		StudentID:     req.StudentID,
+		SubjectID:     req.SubjectID,
+		Message:       nullStringPtr(req.Message),
+		Priority:      req.Priority,
+		Status:        req.Status,
+		TutorID:       nullInt32Ptr(req.TutorID),
+		CreatedAt:     req.CreatedAt,
+		QueuedAt:      req.QueuedAt,
+		ClaimedAt:     nullTimePtr(req.ClaimedAt),
+		ResolvedAt:    nullTimePtr(req.ResolvedAt),
+		WaitSeconds:   req.WaitSeconds,
+		HandleSeconds: req.HandleSeconds,
+		Handbacks:     req.Handbacks,
+		Resolution:    nullStringPtr(req.Resolution),
+	}
+}
+
+type helpQueueEntry struct {
+	helpRequestView
+	Position       int   `json:"position"`
+	WaitingSeconds int64 `json:"waiting_seconds"`
+	OwnStudent     bool  `json:"own_student"`
+}
+
+type helpStats struct {
+	ID               int32   `json:"id"`
+	Name             string  `json:"name"`
+	Resolved         int     `json:"resolved"`
+	AvgWaitSeconds   float64 `json:"avg_wait_seconds"`
+	MaxWaitSeconds   int32   `json:"max_wait_seconds"`
+	AvgHandleSeconds float64 `json:"avg_handle_seconds"`
+	WithinSLA        float64 `json:"within_sla"`
+	Open             int     `json:"open"`
+}
+
+// --- Handler for /help-requests (List, Open) ---
+func (c *Config) HelpRequestsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		c.listHelpRequests(w, r)
+	case http.MethodPost:
+		c.openHelpRequest(w, r)
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// --- Handler for /help-requests/{id} (Get) ---
+func (c *Config) HelpRequestByIDHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	req, err := c.DB.GetHelpRequestByID(r.Context(), int32(id))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, newHelpRequestView(req))
+}
+
+// --- Handler for /help-requests/{id}/{action} (claim, release, resolve) ---
+func (c *Config) HelpRequestActionHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	switch r.PathValue("action") {
+	case "claim":
+		c.claimHelpRequest(w, r, int32(id))
+	case "release":
+		c.releaseHelpRequest(w, r, int32(id))
+	case "resolve":
+		c.resolveHelpRequest(w, r, int32(id))
+	default:
+		http.Error(w, "Not found", http.StatusNotFound)
+	}
+}
+
+// --- Handler for /help-queue ---
+func (c *Config) HelpQueueHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	c.helpQueue(w, r)
+}
+
+func (c *Config) listHelpRequests(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	requests, err := c.DB.ListHelpRequests(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list help requests: %v", err), http.StatusInternalServerError)
+		return
+	}
+	filtered := []helpRequestView{}
+	for _, req := range requests {
+		if s := query.Get("status"); s != "" && req.Status != s {
+			continue
+		}
+		if s := query.Get("student_id"); s != "" && strconv.Itoa(int(req.StudentID)) != s {
+			continue
+		}
+		if s := query.Get("tutor_id"); s != "" && (!req.TutorID.Valid || strconv.Itoa(int(req.TutorID.Int32)) != s) {
+			continue
+		}
+		filtered = append(filtered, newHelpRequestView(req))
+	}
+	respondWithJSON(w, http.StatusOK, filtered)
+}
+
+// openHelpRequest handles POST requests to /help-requests. A student has at
+// most one request in the queue or being handled at a time.
+func (c *Config) openHelpRequest(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		StudentID int32  `json:"student_id"`
+		SubjectID int32  `json:"subject_id"`
+		Message   string `json:"message"`
+		Priority  int32  `json:"priority"`
+	}
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if body.Priority < 0 || body.Priority > maxHelpPriority {
+		http.Error(w, fmt.Sprintf("priority must be between 0 and %d", maxHelpPriority), http.StatusBadRequest)
+		return
+	}
+	if _, err := c.DB.GetStudentByID(r.Context(), body.StudentID); err != nil {
+		http.Error(w, "Student not found", http.StatusBadRequest)
+		return
+	}
+	if _, err := c.DB.GetSubjectByID(r.Context(), body.SubjectID); err != nil {
+		http.Error(w, "Subject not found", http.StatusBadRequest)
+		return
+	}
+	active, err := c.DB.ListActiveHelpRequestsByStudent(r.Context(), body.StudentID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list help requests: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if len(active) > 0 {
+		http.Error(w, "Student already has an open help request", http.StatusConflict)
+		return
+	}
+	var req database.Helprequest
+	err = c.inTx(r.Context(), func(q *database.Queries) error {
+		result, err := q.CreateHelpRequest(r.Context(), database.CreateHelpRequestParams{
+			StudentID: body.StudentID,
+			SubjectID: body.SubjectID,
+			Message:   optionalString(body.Message),
+			Priority:  body.Priority,
+		})
+		if err != nil {
+			return err
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		req, err = publishHelpRequest(r.Context(), q, int32(id), eventHelpOpened)
+		return err
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to open help request: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, newHelpRequestView(req))
+}
+
+// claimHelpRequest handles POST requests to /help-requests/{id}/claim. Only
+// tutors claim, and the status check in the update makes sure two tutors
+// cannot claim the same request.
+func (c *Config) claimHelpRequest(w http.ResponseWriter, r *http.Request, id int32) {
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !by.TutorID.Valid {
+		http.Error(w, "X-Tutor-ID is required to claim a help request", http.StatusBadRequest)
+		return
+	}
+	if _, err := c.DB.GetHelpRequestByID(r.Context(), id); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	c.helpTransition(w, r, id, eventHelpClaimed, func(q *database.Queries) (sql.Result, error) {
+		return q.ClaimHelpRequest(r.Context(), database.ClaimHelpRequestParams{
+			TutorID:   by.TutorID,
+			ClaimedAt: sql.NullTime{Time: time.Now(), Valid: true},
+			ID:        id,
+		})
+	})
+}
+
+// releaseHelpRequest handles POST requests to /help-requests/{id}/release,
+// handing the request back to the queue behind the requests already there.
+func (c *Config) releaseHelpRequest(w http.ResponseWriter, r *http.Request, id int32) {
+	req, ok := c.claimedHelpRequest(w, r, id)
+	if !ok {
+		return
+	}
+	c.helpTransition(w, r, id, eventHelpReleased, func(q *database.Queries) (sql.Result, error) {
+		return q.ReleaseHelpRequest(r.Context(), database.ReleaseHelpRequestParams{
+			QueuedAt: time.Now(),
+			ID:       id,
+			TutorID:  req.TutorID,
+		})
+	})
+}
+
+// resolveHelpRequest handles POST requests to /help-requests/{id}/resolve.
+func (c *Config) resolveHelpRequest(w http.ResponseWriter, r *http.Request, id int32) {
+	var body struct {
+		Resolution string `json:"resolution"`
+	}
+	if err := DecodeJSON(r.Body, &body); err != nil && err != io.EOF {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	req, ok := c.claimedHelpRequest(w, r, id)
+	if !ok {
+		return
+	}
+	c.helpTransition(w, r, id, eventHelpResolved, func(q *database.Queries) (sql.Result, error) {
+		return q.ResolveHelpRequest(r.Context(), database.ResolveHelpRequestParams{
+			ResolvedAt: sql.NullTime{Time: time.Now(), Valid: true},
+			Resolution: optionalString(body.Resolution),
+			ID:         id,
+			TutorID:    req.TutorID,
+		})
+	})
+}
+
+// claimedHelpRequest loads a claimed request that the actor may act on: the
+// tutor who claimed it, or an admin. Release and resolve only apply while
+// that same claim holds, so a stale request cannot act on a later claim.
+func (c *Config) claimedHelpRequest(w http.ResponseWriter, r *http.Request, id int32) (database.Helprequest, bool) {
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return database.Helprequest{}, false
+	}
+	req, err := c.DB.GetHelpRequestByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return req, false
+	}
+	if req.Status != helpClaimed {
+		http.Error(w, fmt.Sprintf("help request is %s", req.Status), http.StatusConflict)
+		return req, false
+	}
+	if by.TutorID.Valid && by.TutorID != req.TutorID {
+		http.Error(w, "Help request is claimed by another tutor", http.StatusForbidden)
+		return req, false
+	}
+	return req, true
+}
+
+// helpTransition applies a state change and publishes it in one transaction,
+// so every change that commits reaches GET /help-queue/stream. The updates
+// only match the state they expect; when nothing changed, someone else got
+// there first and the caller gets a 409.
+func (c *Config) helpTransition(w http.ResponseWriter, r *http.Request, id int32, kind string, apply func(q *database.Queries) (sql.Result, error)) {
+	var req database.Helprequest
+	err := c.inTx(r.Context(), func(q *database.Queries) error {
+		result, err := apply(q)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return errHelpChanged
+		}
+		req, err = publishHelpRequest(r.Context(), q, id, kind)
+		return err
+	})
+	if err != nil {
+		if err == errHelpChanged {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to update help request: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, newHelpRequestView(req))
+}
+
+// publishHelpRequest reads the request back through q and records the change
+// as an event, which is what GET /help-queue/stream pushes to clients.
+func publishHelpRequest(ctx context.Context, q *database.Queries, id int32, kind string) (database.Helprequest, error) {
+	req, err := q.GetHelpRequestByID(ctx, id)
+	if err != nil {
+		return req, err
+	}
+	return req, emitEventWith(ctx, q, kind, req.StudentID, newHelpRequestView(req))
+}
+
+// helpQueue handles GET requests to /help-queue. Open requests are ordered by
+// priority and then by how long they have waited; with ?tutor_id= the tutor's
+// own students come first.
+func (c *Config) helpQueue(w http.ResponseWriter, r *http.Request) {
+	var viewer actor
+	if s := r.URL.Query().Get("tutor_id"); s != "" {
+		tutorID, err := strconv.Atoi(s)
+		if err != nil {
+			http.Error(w, "Invalid tutor_id format", http.StatusBadRequest)
+			return
+		}
+		viewer.TutorID = sql.NullInt32{Int32: int32(tutorID), Valid: true}
+	}
+	open, err := c.DB.ListHelpRequestsByStatus(r.Context(), helpOpen)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list help requests: %v", err), http.StatusInternalServerError)
+		return
+	}
+	now := time.Now()
+	queue := []helpQueueEntry{}
+	for _, req := range open {
+		entry := helpQueueEntry{
+			helpRequestView: newHelpRequestView(req),
+			WaitingSeconds:  int64(req.WaitSeconds) + int64(now.Sub(req.QueuedAt).Seconds()),
+		}
+		if viewer.TutorID.Valid {
+			switch err := c.checkActsOn(r.Context(), viewer, req.StudentID); err {
+			case nil:
+				entry.OwnStudent = true
+			case errNotResponsible:
+			default:
+				http.Error(w, fmt.Sprintf("Failed to check tutor students: %v", err), http.StatusInternalServerError)
+				return
+			}
+		}
+		queue = append(queue, entry)
+	}
+	sort.SliceStable(queue, func(i, j int) bool {
+		return queue[i].OwnStudent && !queue[j].OwnStudent
+	})
+	for i := range queue {
+		queue[i].Position = i + 1
+	}
+	respondWithJSON(w, http.StatusOK, queue)
+}
+
+// HelpQueueStreamHandler handles GET requests to /help-queue/stream. It is a
+// server-sent event stream of the help_request.* events, resuming after the
+// Last-Event-ID header or ?after= when given and from now otherwise.
+func (c *Config) HelpQueueStreamHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	after := r.Header.Get("Last-Event-ID")
+	if after == "" {
+		after = r.URL.Query().Get("after")
+	}
+	var lastID int32
+	if after != "" {
+		id, err := strconv.ParseInt(after, 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid event id", http.StatusBadRequest)
+			return
+		}
+		lastID = int32(id)
+	} else {
+		id, err := c.DB.GetLastEventID(r.Context())
+		if err != nil && err != sql.ErrNoRows {
+			http.Error(w, fmt.Sprintf("Failed to read events: %v", err), http.StatusInternalServerError)
+			return
+		}
+		lastID = id
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ticker := time.NewTicker(helpStreamInterval)
+	defer ticker.Stop()
+	idle := time.Duration(0)
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+		events, err := c.DB.ListEventsByKindPrefixAfter(r.Context(), database.ListEventsByKindPrefixAfterParams{
+			Kind:  "help_request.%",
+			ID:    lastID,
+			Limit: 100,
+		})
+		if err != nil {
+			return
+		}
+		for _, e := range events {
+			fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Kind, e.Payload)
+			lastID = e.ID
+		}
+		idle += helpStreamInterval
+		if len(events) > 0 {
+			idle = 0
+		} else if idle >= helpStreamKeepAlive {
+			fmt.Fprint(w, ": keep-alive\n\n")
+			idle = 0
+		}
+		flusher.Flush()
+	}
+}
+
+// --- Handler for /help-requests/stats ---
+// Resolved requests are grouped per tutor and per subject, optionally limited
+// to those resolved between ?from= and ?to= (YYYY-MM-DD, inclusive). A request
+// is within the SLA when it waited no longer than help_sla_minutes in total.
+// ?term_id and ?cohort_id narrow it to requests queued by the scope's
+// students within the term.
+func (c *Config) HelpStatsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var from, to time.Time
+	var err error
+	if s := r.URL.Query().Get("from"); s != "" {
+		if from, err = time.Parse(termDateLayout, s); err != nil {
+			http.Error(w, "Invalid from date", http.StatusBadRequest)
+			return
+		}
+	}
+	if s := r.URL.Query().Get("to"); s != "" {
+		if to, err = time.Parse(termDateLayout, s); err != nil {
+			http.Error(w, "Invalid to date", http.StatusBadRequest)
+			return
+		}
+		to = to.AddDate(0, 0, 1)
+	}
+	sc, err := c.scopeFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	requests, err := c.DB.ListHelpRequests(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list help requests: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	type totals struct {
+		resolved, withinSLA, open int
+		wait, handle              int64
+		maxWait                   int32
+	}
+	byTutor := map[int32]*totals{}
+	bySubject := map[int32]*totals{}
+	get := func(m map[int32]*totals, id int32) *totals {
+		if m[id] == nil {
+			m[id] = &totals{}
+		}
+		return m[id]
+	}
+	for _, req := range requests {
+		if !sc.hasStudent(req.StudentID) || !sc.covers(req.QueuedAt) {
+			continue
+		}
+		if req.Status == helpOpen {
+			get(bySubject, req.SubjectID).open++
+			continue
+		}
+		if req.Status != helpResolved || !req.ResolvedAt.Valid {
+			continue
+		}
+		if (!from.IsZero() && req.ResolvedAt.Time.Before(from)) || (!to.IsZero() && !req.ResolvedAt.Time.Before(to)) {
+			continue
+		}
+		groups := []*totals{get(bySubject, req.SubjectID)}
+		if req.TutorID.Valid {
+			groups = append(groups, get(byTutor, req.TutorID.Int32))
+		}
+		for _, t := range groups {
+			t.resolved++
+			t.wait += int64(req.WaitSeconds)
+			t.handle += int64(req.HandleSeconds)
+			t.maxWait = max(t.maxWait, req.WaitSeconds)
+			if time.Duration(req.WaitSeconds)*time.Second <= c.HelpSLA {
+				t.withinSLA++
+			}
+		}
+	}
+	build := func(m map[int32]*totals, name func(id int32) string) []helpStats {
+		stats := []helpStats{}
+		for id, t := range m {
+			s := helpStats{ID: id, Name: name(id), Resolved: t.resolved, MaxWaitSeconds: t.maxWait, Open: t.open}
+			if t.resolved > 0 {
+				s.AvgWaitSeconds = float64(t.wait) / float64(t.resolved)
+				s.AvgHandleSeconds = float64(t.handle) / float64(t.resolved)
+				s.WithinSLA = float64(t.withinSLA) / float64(t.resolved) * 100
+			}
+			stats = append(stats, s)
+		}
+		sort.Slice(stats, func(i, j int) bool { return stats[i].ID < stats[j].ID })
+		return stats
+	}
+	tutors, err := c.DB.GetAllTutors(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list tutors: %v", err), http.StatusInternalServerError)
+		return
+	}
+	tutorNames := map[int32]string{}
+	for _, t := range tutors {
+		tutorNames[t.ID] = t.Name
+	}
+	subjects, err := c.DB.ListSubjects(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list subjects: %v", err), http.StatusInternalServerError)
+		return
+	}
+	subjectNames := map[int32]string{}
+	for _, s := range subjects {
+		subjectNames[s.ID] = s.Name
+	}
+	respondWithJSON(w, http.StatusOK, map[string]any{
+		"sla_minutes": c.HelpSLA.Minutes(),
+		"by_tutor":    build(byTutor, func(id int32) string { return tutorNames[id] }),
+		"by_subject":  build(bySubject, func(id int32) string { return subjectNames[id] }),
+	})
+}
@@ -0,0 +1,499 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/wilgnert/webtutoria/internal/database"
+)
+
+type learningPathView struct {
+	ID          int32                    `json:"id"`
+	Name        string                   `json:"name"`
+	Description string                   `json:"description"`
+	Subjects    []subjectsWithCategories `json:"subjects"`
+}
+
+type learningPathStep struct {
+	Position  int32  `json:"position"`
+	SubjectID int32  `json:"subject_id"`
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	Class     string `json:"class"`
+	Completed bool   `json:"completed"`
+}
+
+type learningPathProgress struct {
+	PathID      int32                   `json:"path_id"`
+	Name        string                  `json:"name"`
+	Total       int                     `json:"total"`
+	Completed   int                     `json:"completed"`
+	Percent     float64                 `json:"percent"`
+	NextSubject *subjectsWithCategories `json:"next_subject"`
+	Steps       []learningPathStep      `json:"steps"`
+}
+
+type learningPathBody struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	SubjectIDs  []int32 `json:"subject_ids"`
+}
+
+// --- Handler for /learning-paths (List, Create) ---
+func (c *Config) LearningPathsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		c.listLearningPaths(w, r)
+	case http.MethodPost:
+		c.createLearningPath(w, r)
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// --- Handler for /learning-paths/{id} (Get, Update, Delete) ---
+func (c *Config) LearningPathByIDHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		c.getLearningPathById(w, r, int32(id))
+	case http.MethodPut:
+		c.updateLearningPathById(w, r, int32(id))
+	case http.MethodDelete:
+		c.deleteLearningPathById(w, r, int32(id))
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// --- Handler for /learning-paths/{id}/students (List, Enroll) ---
+func (c *Config) LearningPathStudentsHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		c.listLearningPathEnrollments(w, r, int32(id))
+	case http.MethodPost:
+		c.enrollInLearningPath(w, r, int32(id))
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// --- Handler for /learning-paths/{id}/students/{student_id} (Unenroll) ---
+func (c *Config) LearningPathStudentByIDHandler(w http.ResponseWriter, r *http.Request) {
+	pathID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	studentID, err := strconv.Atoi(r.PathValue("student_id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := c.checkActsOn(r.Context(), by, int32(studentID)); err != nil {
+		actorError(w, err)
+		return
+	}
+	result, err := c.DB.UnenrollStudentFromLearningPath(r.Context(), database.UnenrollStudentFromLearningPathParams{
+		StudentID: int32(studentID),
+		PathID:    int32(pathID),
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to unenroll student: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// --- Handler for /students/{id}/learning-paths (progress per enrolled path) ---
+func (c *Config) StudentLearningPathsHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	progress, err := c.studentLearningPathProgress(r.Context(), int32(id))
+	if err == sql.ErrNoRows {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to compute learning path progress: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, progress)
+}
+
+// --- Handler for /students/{id}/learning-paths/next (next subject per path) ---
+func (c *Config) StudentNextSubjectsHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	progress, err := c.studentLearningPathProgress(r.Context(), int32(id))
+	if err == sql.ErrNoRows {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to compute learning path progress: %v", err), http.StatusInternalServerError)
+		return
+	}
+	type nextSubject struct {
+		PathID   int32                   `json:"path_id"`
+		PathName string                  `json:"path_name"`
+		Subject  *subjectsWithCategories `json:"subject"`
+	}
+	next := []nextSubject{}
+	for _, p := range progress {
+		next = append(next, nextSubject{PathID: p.PathID, PathName: p.Name, Subject: p.NextSubject})
+	}
+	respondWithJSON(w, http.StatusOK, next)
+}
+
+func (c *Config) learningPathView(ctx context.Context, path database.Learningpath) (learningPathView, error) {
+	view := learningPathView{
+		ID:          path.ID,
+		Name:        path.Name,
+		Description: path.Description.String,
+		Subjects:    []subjectsWithCategories{},
+	}
+	steps, err := c.DB.ListLearningPathSubjects(ctx, path.ID)
+	if err != nil {
+		return view, err
+	}
+	for _, step := range steps {
+		sub, err := c.DB.GetSubjectByID(ctx, step.SubjectID)
+		if err != nil {
+			return view, err
+		}
+		view.Subjects = append(view.Subjects, c.populateCategoriesOne(ctx, sub))
+	}
+	return view, nil
+}
+
+func (c *Config) listLearningPaths(w http.ResponseWriter, r *http.Request) {
+	paths, err := c.DB.ListLearningPaths(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error getting learning paths: %v", err), http.StatusInternalServerError)
+		return
+	}
+	views := []learningPathView{}
+	for _, path := range paths {
+		view, err := c.learningPathView(r.Context(), path)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Error getting learning path subjects: %v", err), http.StatusInternalServerError)
+			return
+		}
+		views = append(views, view)
+	}
+	respondWithJSON(w, http.StatusOK, views)
+}
+
+func (c *Config) createLearningPath(w http.ResponseWriter, r *http.Request) {
+	var body learningPathBody
+	defer r.Body.Close()
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, fmt.Sprintf("Error decoding JSON: %v", err), http.StatusBadRequest)
+		return
+	}
+	if err := c.validateLearningPathBody(r.Context(), body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// the path and its subjects are created together
+	var id int64
+	err := c.inTx(r.Context(), func(q *database.Queries) error {
+		result, err := q.CreateLearningPath(r.Context(), database.CreateLearningPathParams{
+			Name: body.Name,
+			Description: sql.NullString{
+				String: body.Description,
+				Valid:  len(body.Description) > 0,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		id, err = result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		return setLearningPathSubjects(r.Context(), q, int32(id), body.SubjectIDs)
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			http.Error(w, "name already exists", http.StatusConflict)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Error creating learning path: %v", err), http.StatusInternalServerError)
+		return
+	}
+	path, err := c.DB.GetLearningPathByID(r.Context(), int32(id))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error creating learning path: %v", err), http.StatusInternalServerError)
+		return
+	}
+	view, err := c.learningPathView(r.Context(), path)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error getting learning path subjects: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, view)
+}
+
+func (c *Config) getLearningPathById(w http.ResponseWriter, r *http.Request, id int32) {
+	path, err := c.DB.GetLearningPathByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	view, err := c.learningPathView(r.Context(), path)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error getting learning path subjects: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, view)
+}
+
+// updateLearningPathById handles PUT requests to /learning-paths/{id}. The
+// subject list in the body replaces the current one, in order.
+func (c *Config) updateLearningPathById(w http.ResponseWriter, r *http.Request, id int32) {
+	if _, err := c.DB.GetLearningPathByID(r.Context(), id); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	var body learningPathBody
+	defer r.Body.Close()
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, fmt.Sprintf("Error decoding JSON: %v", err), http.StatusBadRequest)
+		return
+	}
+	if err := c.validateLearningPathBody(r.Context(), body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err := c.inTx(r.Context(), func(q *database.Queries) error {
+		_, err := q.UpdateLearningPath(r.Context(), database.UpdateLearningPathParams{
+			Name: body.Name,
+			Description: sql.NullString{
+				String: body.Description,
+				Valid:  len(body.Description) > 0,
+			},
+			ID: id,
+		})
+		if err != nil {
+			return err
+		}
+		return setLearningPathSubjects(r.Context(), q, id, body.SubjectIDs)
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			http.Error(w, "name already exists", http.StatusConflict)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Error updating learning path: %v", err), http.StatusInternalServerError)
+		return
+	}
+	c.getLearningPathById(w, r, id)
+}
+
+func (c *Config) deleteLearningPathById(w http.ResponseWriter, r *http.Request, id int32) {
+	if err := c.DB.DeleteLearningPath(r.Context(), id); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to delete learning path: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *Config) validateLearningPathBody(ctx context.Context, body learningPathBody) error {
+	if body.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	seen := map[int32]bool{}
+	for _, subjectID := range body.SubjectIDs {
+		if seen[subjectID] {
+			return fmt.Errorf("subject %d appears more than once", subjectID)
+		}
+		seen[subjectID] = true
+		if _, err := c.DB.GetSubjectByID(ctx, subjectID); err != nil {
+			return fmt.Errorf("unknown subject %d", subjectID)
+		}
+	}
+	return nil
+}
+
+// setLearningPathSubjects replaces the path's subjects; callers run it in a
+// transaction so a failed insert does not leave the path empty.
+func setLearningPathSubjects(ctx context.Context, q *database.Queries, pathID int32, subjectIDs []int32) error {
+	if err := q.DeleteLearningPathSubjects(ctx, pathID); err != nil {
+		return err
+	}
+	for i, subjectID := range subjectIDs {
+		_, err := q.CreateLearningPathSubject(ctx, database.CreateLearningPathSubjectParams{
+			PathID:    pathID,
+			SubjectID: subjectID,
+			Position:  int32(i),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Config) listLearningPathEnrollments(w http.ResponseWriter, r *http.Request, pathID int32) {
+	if _, err := c.DB.GetLearningPathByID(r.Context(), pathID); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	sc, err := c.scopeFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	enrollments, err := c.DB.ListLearningPathEnrollments(r.Context(), pathID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list enrollments: %v", err), http.StatusInternalServerError)
+		return
+	}
+	scoped := []database.Studentlearningpath{}
+	for _, e := range enrollments {
+		if sc.hasStudent(e.StudentID) {
+			scoped = append(scoped, e)
+		}
+	}
+	respondWithJSON(w, http.StatusOK, scoped)
+}
+
+func (c *Config) enrollInLearningPath(w http.ResponseWriter, r *http.Request, pathID int32) {
+	var body struct {
+		StudentID int32 `json:"student_id"`
+	}
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, "Invalid body", http.StatusBadRequest)
+		return
+	}
+	if _, err := c.DB.GetLearningPathByID(r.Context(), pathID); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if _, err := c.DB.GetStudentByID(r.Context(), body.StudentID); err != nil {
+		http.Error(w, fmt.Sprintf("Unknown student %d", body.StudentID), http.StatusBadRequest)
+		return
+	}
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := c.checkActsOn(r.Context(), by, body.StudentID); err != nil {
+		actorError(w, err)
+		return
+	}
+	_, err = c.DB.EnrollStudentInLearningPath(r.Context(), database.EnrollStudentInLearningPathParams{
+		StudentID: body.StudentID,
+		PathID:    pathID,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			http.Error(w, "Student is already enrolled in this learning path", http.StatusConflict)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to enroll student: %v", err), http.StatusInternalServerError)
+		return
+	}
+	c.listLearningPathEnrollments(w, r, pathID)
+}
+
+// studentLearningPathProgress computes, for every path the student is
+// enrolled in, how many of its subjects have a passing completion and the
+// first subject in path order that does not.
+func (c *Config) studentLearningPathProgress(ctx context.Context, studentID int32) ([]learningPathProgress, error) {
+	if _, err := c.DB.GetStudentByID(ctx, studentID); err != nil {
+		return nil, err
+	}
+	enrollments, err := c.DB.ListStudentLearningPaths(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	completions, err := c.DB.ListStudentSubjectCompletionsByStudent(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	passed := map[int32]bool{}
+	for _, comp := range completions {
+		if comp.Passed {
+			passed[comp.SubjectID] = true
+		}
+	}
+	progress := []learningPathProgress{}
+	for _, enrollment := range enrollments {
+		path, err := c.DB.GetLearningPathByID(ctx, enrollment.PathID)
+		if err != nil {
+			return nil, err
+		}
+		steps, err := c.DB.ListLearningPathSubjects(ctx, path.ID)
+		if err != nil {
+			return nil, err
+		}
+		p := learningPathProgress{PathID: path.ID, Name: path.Name, Total: len(steps), Steps: []learningPathStep{}}
+		for _, step := range steps {
+			sub, err := c.DB.GetSubjectByID(ctx, step.SubjectID)
+			if err != nil {
+				return nil, err
+			}
+			p.Steps = append(p.Steps, learningPathStep{
+				Position:  step.Position,
+				SubjectID: sub.ID,
+				Code:      sub.Code,
+				Name:      sub.Name,
+				Class:     sub.Class,
+				Completed: passed[sub.ID],
+			})
+			if passed[sub.ID] {
+				p.Completed++
+			} else if p.NextSubject == nil {
+				next := c.populateCategoriesOne(ctx, sub)
+				p.NextSubject = &next
+			}
+		}
+		if p.Total > 0 {
+			p.Percent = float64(p.Completed) / float64(p.Total) * 100
+		}
+		progress = append(progress, p)
+	}
+	return progress, nil
+}
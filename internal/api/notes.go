@@ -0,0 +1,464 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wilgnert/webtutoria/internal/database"
+)
+
+const (
+	noteTutor   = "tutor"
+	noteStaff   = "staff"
+	noteStudent = "student"
+)
+
+func validNoteVisibility(v string) bool {
+	return v == noteTutor || v == noteStaff || v == noteStudent
+}
+
+// noteViewer decides which notes a request may read. Student-visible notes
+// are readable by everyone, staff notes by tutors and admins, and tutor notes
+// only by their author and the tutors responsible for the student.
+//
+// A request sees what the student would see unless it widens the view: tutors
+// by sending X-Tutor-ID, admins with ?audience=staff. ?audience=student
+// narrows a tutor's request back to the student view.
+type noteViewer struct {
+	by          actor
+	studentView bool
+	responsible map[int32]bool
+}
+
+func (c *Config) noteViewerFromRequest(r *http.Request) (*noteViewer, error) {
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	audience := r.URL.Query().Get("audience")
+	if audience != "" && audience != noteStudent && audience != noteStaff {
+		return nil, fmt.Errorf("audience must be student or staff")
+	}
+	return &noteViewer{
+		by:          by,
+		studentView: audience == noteStudent || (audience == "" && !by.TutorID.Valid),
+		responsible: map[int32]bool{},
+	}, nil
+}
+
+// noteView is the response shape of a note, with unset links as null.
+type noteView struct {
+	ID            int32     `json:"id"`
+	StudentID     int32     `json:"student_id"`
+	CompletionID  *int32    `json:"completion_id"`
+	AuthorTutorID *int32    `json:"author_tutor_id"`
+	Author        string    `json:"author"`
+	Visibility    string    `json:"visibility"`
+	Body          string    `json:"body"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func newNoteView(n database.Note) noteView {
+	return noteView{
+		ID:            n.ID,
+		StudentID:     n.StudentID,
+		CompletionID:  nullInt32Ptr(n.CompletionID),
+		AuthorTutorID: nullInt32Ptr(n.AuthorTutorID),
+		Author:        n.Author,
+		Visibility:    n.Visibility,
+		Body:          n.Body,
+		CreatedAt:     n.CreatedAt,
+		UpdatedAt:     n.UpdatedAt,
+	}
+}
+
+func newNoteViews(notes []database.Note) []noteView {
+	views := make([]noteView, 0, len(notes))
+	for _, n := range notes {
+		views = append(views, newNoteView(n))
+	}
+	return views
+}
+
+func (c *Config) canSeeNote(ctx context.Context, v *noteViewer, n database.Note) (bool, error) {
+	if n.Visibility == noteStudent {
+		return true, nil
+	}
+	if v.studentView {
+		return false, nil
+	}
+	if n.Visibility == noteStaff || isNoteAuthor(v.by, n) {
+		return true, nil
+	}
+	if !v.by.TutorID.Valid {
+		return false, nil
+	}
+	ok, seen := v.responsible[n.StudentID]
+	if !seen {
+		switch err := c.checkActsOn(ctx, v.by, n.StudentID); err {
+		case nil:
+			ok = true
+		case errNotResponsible:
+		default:
+			return false, err
+		}
+		v.responsible[n.StudentID] = ok
+	}
+	return ok, nil
+}
+
+func (c *Config) visibleNotes(ctx context.Context, v *noteViewer, notes []database.Note) ([]database.Note, error) {
+	visible := []database.Note{}
+	for _, n := range notes {
+		ok, err := c.canSeeNote(ctx, v, n)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			visible = append(visible, n)
+		}
+	}
+	return visible, nil
+}
+
+func isNoteAuthor(by actor, n database.Note) bool {
+	return by.TutorID == n.AuthorTutorID
+}
+
+// --- Handler for /students/{id}/notes (List, Create) ---
+func (c *Config) StudentNotesHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		c.listStudentNotes(w, r, int32(id))
+	case http.MethodPost:
+		c.createNote(w, r, int32(id))
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// --- Handler for /notes (Search) ---
+func (c *Config) NotesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	c.searchNotes(w, r)
+}
+
+// --- Handler for /notes/{id} (Get, Update, Delete) ---
+func (c *Config) NoteByIDHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		c.getNote(w, r, int32(id))
+	case http.MethodPut:
+		c.updateNote(w, r, int32(id))
+	case http.MethodDelete:
+		c.deleteNote(w, r, int32(id))
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// --- Handler for /notes/{id}/history ---
+func (c *Config) NoteHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	note, ok := c.readableNote(w, r, int32(id))
+	if !ok {
+		return
+	}
+	viewer, err := c.noteViewerFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	revisions, err := c.DB.ListNoteRevisions(r.Context(), int32(id))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list note history: %v", err), http.StatusInternalServerError)
+		return
+	}
+	// each revision is only shown to those who could read it at the time, so
+	// a note made public does not reveal its private drafts
+	visible := []database.Noterevision{}
+	for _, rev := range revisions {
+		past := note
+		past.Visibility = rev.Visibility
+		ok, err := c.canSeeNote(r.Context(), viewer, past)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to check note visibility: %v", err), http.StatusInternalServerError)
+			return
+		}
+		if ok {
+			visible = append(visible, rev)
+		}
+	}
+	respondWithJSON(w, http.StatusOK, visible)
+}
+
+// listStudentNotes handles GET requests to /students/{id}/notes, optionally
+// narrowed with ?completion_id= and ?q=.
+func (c *Config) listStudentNotes(w http.ResponseWriter, r *http.Request, studentID int32) {
+	if _, err := c.DB.GetStudentByID(r.Context(), studentID); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	viewer, err := c.noteViewerFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	notes, err := c.DB.ListNotesByStudent(r.Context(), studentID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list notes: %v", err), http.StatusInternalServerError)
+		return
+	}
+	completion := r.URL.Query().Get("completion_id")
+	q := strings.ToLower(r.URL.Query().Get("q"))
+	filtered := []database.Note{}
+	for _, n := range notes {
+		if completion != "" && (!n.CompletionID.Valid || strconv.Itoa(int(n.CompletionID.Int32)) != completion) {
+			continue
+		}
+		if q != "" && !strings.Contains(strings.ToLower(n.Body), q) {
+			continue
+		}
+		filtered = append(filtered, n)
+	}
+	visible, err := c.visibleNotes(r.Context(), viewer, filtered)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to check note visibility: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, newNoteViews(visible))
+}
+
+// searchNotes handles GET requests to /notes?q=, searching every note the
+// caller may read.
+func (c *Config) searchNotes(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query().Get("q")
+	if len(q) < 3 {
+		http.Error(w, "q must have at least 3 characters", http.StatusBadRequest)
+		return
+	}
+	viewer, err := c.noteViewerFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	escaped := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(q)
+	notes, err := c.DB.SearchNotes(r.Context(), "%"+escaped+"%")
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to search notes: %v", err), http.StatusInternalServerError)
+		return
+	}
+	visible, err := c.visibleNotes(r.Context(), viewer, notes)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to check note visibility: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, newNoteViews(visible))
+}
+
+// createNote handles POST requests to /students/{id}/notes. Bodies are
+// markdown and stored as written.
+func (c *Config) createNote(w http.ResponseWriter, r *http.Request, studentID int32) {
+	var body struct {
+		Body         string `json:"body"`
+		Visibility   string `json:"visibility"`
+		CompletionID *int32 `json:"completion_id"`
+	}
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(body.Body) == "" {
+		http.Error(w, "body is required", http.StatusBadRequest)
+		return
+	}
+	if body.Visibility == "" {
+		body.Visibility = noteTutor
+	}
+	if !validNoteVisibility(body.Visibility) {
+		http.Error(w, "visibility must be tutor, staff or student", http.StatusBadRequest)
+		return
+	}
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := c.DB.GetStudentByID(r.Context(), studentID); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if err := c.checkActsOn(r.Context(), by, studentID); err != nil {
+		actorError(w, err)
+		return
+	}
+	completion := sql.NullInt32{}
+	if body.CompletionID != nil {
+		comp, err := c.DB.GetStudentSubjectCompletionByID(r.Context(), *body.CompletionID)
+		if err != nil || comp.StudentID != studentID {
+			http.Error(w, "Completion not found for this student", http.StatusBadRequest)
+			return
+		}
+		completion = sql.NullInt32{Int32: comp.ID, Valid: true}
+	}
+	result, err := c.DB.CreateNote(r.Context(), database.CreateNoteParams{
+		StudentID:     studentID,
+		CompletionID:  completion,
+		AuthorTutorID: by.TutorID,
+		Author:        by.Name,
+		Visibility:    body.Visibility,
+		Body:          body.Body,
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to create note: %v", err), http.StatusInternalServerError)
+		return
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to retrieve new note ID: %v", err), http.StatusInternalServerError)
+		return
+	}
+	note, err := c.DB.GetNoteByID(r.Context(), int32(id))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get note: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, newNoteView(note))
+}
+
+// readableNote loads a note the caller may read, answering 404 otherwise so
+// private notes do not reveal that they exist.
+func (c *Config) readableNote(w http.ResponseWriter, r *http.Request, id int32) (database.Note, bool) {
+	note, err := c.DB.GetNoteByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return note, false
+	}
+	viewer, err := c.noteViewerFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return note, false
+	}
+	ok, err := c.canSeeNote(r.Context(), viewer, note)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to check note visibility: %v", err), http.StatusInternalServerError)
+		return note, false
+	}
+	if !ok {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return note, false
+	}
+	return note, true
+}
+
+func (c *Config) getNote(w http.ResponseWriter, r *http.Request, id int32) {
+	note, ok := c.readableNote(w, r, id)
+	if !ok {
+		return
+	}
+	respondWithJSON(w, http.StatusOK, newNoteView(note))
+}
+
+// updateNote handles PUT requests to /notes/{id}. Only the author edits a
+// note, and the version being replaced is kept in its history.
+func (c *Config) updateNote(w http.ResponseWriter, r *http.Request, id int32) {
+	var body struct {
+		Body       string `json:"body"`
+		Visibility string `json:"visibility"`
+	}
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	note, ok := c.readableNote(w, r, id)
+	if !ok {
+		return
+	}
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !isNoteAuthor(by, note) {
+		http.Error(w, "Only the author can edit a note", http.StatusForbidden)
+		return
+	}
+	if body.Body == "" {
+		body.Body = note.Body
+	}
+	if body.Visibility == "" {
+		body.Visibility = note.Visibility
+	}
+	if !validNoteVisibility(body.Visibility) {
+		http.Error(w, "visibility must be tutor, staff or student", http.StatusBadRequest)
+		return
+	}
+	err = c.inTx(r.Context(), func(q *database.Queries) error {
+		if _, err := q.CreateNoteRevision(r.Context(), database.CreateNoteRevisionParams{
+			NoteID:     note.ID,
+			Body:       note.Body,
+			Visibility: note.Visibility,
+			EditedBy:   by.Name,
+		}); err != nil {
+			return err
+		}
+		return q.UpdateNote(r.Context(), database.UpdateNoteParams{
+			Body:       body.Body,
+			Visibility: body.Visibility,
+			ID:         note.ID,
+		})
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to update note: %v", err), http.StatusInternalServerError)
+		return
+	}
+	c.getNote(w, r, id)
+}
+
+func (c *Config) deleteNote(w http.ResponseWriter, r *http.Request, id int32) {
+	note, ok := c.readableNote(w, r, id)
+	if !ok {
+		return
+	}
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !isNoteAuthor(by, note) {
+		http.Error(w, "Only the author can delete a note", http.StatusForbidden)
+		return
+	}
+	if err := c.DB.DeleteNote(r.Context(), id); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to delete note: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
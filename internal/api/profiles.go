@@ -0,0 +1,285 @@
+package api
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wilgnert/webtutoria/internal/database"
+)
+
+var localePattern = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+
+// profile holds the optional contact and enrollment fields shared by
+// students and tutors. Empty strings clear a field.
+type profile struct {
+	Email         string `json:"email"`
+	PreferredName string `json:"preferred_name"`
+	Pronouns      string `json:"pronouns"`
+	Timezone      string `json:"timezone"`
+	Locale        string `json:"locale"`
+	EnrolledOn    string `json:"enrolled_on"`
+	Bio           string `json:"bio"`
+	AvatarURL     string `json:"avatar_url"`
+}
+
+func (p profile) validate() error {
+	if p.Email != "" {
+		addr, err := mail.ParseAddress(p.Email)
+		if err != nil || addr.Address != p.Email {
+			return fmt.Errorf("email is not a valid address")
+		}
+	}
+	if len(p.PreferredName) > 255 {
+		return fmt.Errorf("preferred_name is too long")
+	}
+	if len(p.Pronouns) > 64 {
+		return fmt.Errorf("pronouns is too long")
+	}
+	if p.Timezone != "" {
+		if _, err := time.LoadLocation(p.Timezone); err != nil {
+			return fmt.Errorf("timezone must be an IANA name such as America/Sao_Paulo")
+		}
+	}
+	if p.Locale != "" && (len(p.Locale) > 35 || !localePattern.MatchString(p.Locale)) {
+		return fmt.Errorf("locale must be a language tag such as pt-BR")
+	}
+	if p.EnrolledOn != "" {
+		if _, err := time.Parse(termDateLayout, p.EnrolledOn); err != nil {
+			return fmt.Errorf("enrolled_on must be a YYYY-MM-DD date")
+		}
+	}
+	if p.AvatarURL != "" {
+		u, err := url.Parse(p.AvatarURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || len(p.AvatarURL) > 512 {
+			return fmt.Errorf("avatar_url must be an http(s) URL")
+		}
+	}
+	return nil
+}
+
+func (p profile) enrolledOn() sql.NullTime {
+	t, err := time.Parse(termDateLayout, p.EnrolledOn)
+	if err != nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t, Valid: true}
+}
+
+func optionalString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: len(s) > 0}
+}
+
+func (p profile) studentParams(id int32) database.UpdateStudentProfileParams {
+	return database.UpdateStudentProfileParams{
+		Email:         optionalString(p.Email),
+		PreferredName: optionalString(p.PreferredName),
+		Pronouns:      optionalString(p.Pronouns),
+		Timezone:      optionalString(p.Timezone),
+		Locale:        optionalString(p.Locale),
+		EnrolledOn:    p.enrolledOn(),
+		Bio:           optionalString(p.Bio),
+		AvatarUrl:     optionalString(p.AvatarURL),
+		ID:            id,
+	}
+}
+
+func (p profile) tutorParams(id int32) database.UpdateTutorProfileParams {
+	return database.UpdateTutorProfileParams{
+		Email:         optionalString(p.Email),
+		PreferredName: optionalString(p.PreferredName),
+		Pronouns:      optionalString(p.Pronouns),
+		Timezone:      optionalString(p.Timezone),
+		Locale:        optionalString(p.Locale),
+		EnrolledOn:    p.enrolledOn(),
+		Bio:           optionalString(p.Bio),
+		AvatarUrl:     optionalString(p.AvatarURL),
+		ID:            id,
+	}
+}
+
+// profileView is the response shape of a profile, with unset fields as null.
+type profileView struct {
+	Email         *string    `json:"email"`
+	PreferredName *string    `json:"preferred_name"`
+	Pronouns      *string    `json:"pronouns"`
+	Timezone      *string    `json:"timezone"`
+	Locale        *string    `json:"locale"`
+	EnrolledOn    *time.Time `json:"enrolled_on"`
+	Bio           *string    `json:"bio"`
+	AvatarURL     *string    `json:"avatar_url"`
+}
+
+type studentView struct {
+	ID     int32   `json:"id"`
+	Name   string  `json:"name"`
+	Class  *string `json:"class"`
+	Status string  `json:"status"`
+	profileView
+}
+
+type tutorView struct {
+	ID           int32  `json:"id"`
+	Name         string `json:"name"`
+	ChannelID    string `json:"channel_id"`
+	RoleID       string `json:"role_id"`
+	MaxStudents  *int32 `json:"max_students"`
+	SupervisorID *int32 `json:"supervisor_id"`
+	profileView
+}
+
+func newStudentView(s database.Student) studentView {
+	return studentView{
+		ID:     s.ID,
+		Name:   s.Name,
+		Class:  nullStringPtr(s.Class),
+		Status: s.Status,
+		profileView: profileView{
+			Email:         nullStringPtr(s.Email),
+			PreferredName: nullStringPtr(s.PreferredName),
+			Pronouns:      nullStringPtr(s.Pronouns),
+			Timezone:      nullStringPtr(s.Timezone),
+			Locale:        nullStringPtr(s.Locale),
+			EnrolledOn:    nullTimePtr(s.EnrolledOn),
+			Bio:           nullStringPtr(s.Bio),
+			AvatarURL:     nullStringPtr(s.AvatarUrl),
+		},
+	}
+}
+
+func newStudentViews(studs []database.Student) []studentView {
+	views := make([]studentView, 0, len(studs))
+	for _, s := range studs {
+		views = append(views, newStudentView(s))
+	}
+	return views
+}
+
+func newTutorView(t database.Tutor) tutorView {
+	return tutorView{
+		ID:           t.ID,
+		Name:         t.Name,
+		ChannelID:    t.ChannelID,
+		RoleID:       t.RoleID,
+		MaxStudents:  nullInt32Ptr(t.MaxStudents),
+		SupervisorID: nullInt32Ptr(t.SupervisorID),
+		profileView: profileView{
+			Email:         nullStringPtr(t.Email),
+			PreferredName: nullStringPtr(t.PreferredName),
+			Pronouns:      nullStringPtr(t.Pronouns),
+			Timezone:      nullStringPtr(t.Timezone),
+			Locale:        nullStringPtr(t.Locale),
+			EnrolledOn:    nullTimePtr(t.EnrolledOn),
+			Bio:           nullStringPtr(t.Bio),
+			AvatarURL:     nullStringPtr(t.AvatarUrl),
+		},
+	}
+}
+
+func newTutorViews(tutors []database.Tutor) []tutorView {
+	views := make([]tutorView, 0, len(tutors))
+	for _, t := range tutors {
+		views = append(views, newTutorView(t))
+	}
+	return views
+}
+
+func nullInt32Ptr(n sql.NullInt32) *int32 {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Int32
+}
+
+func nullTimePtr(n sql.NullTime) *time.Time {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Time
+}
+
+// profileError answers a failed profile update, telling taken emails apart.
+func profileError(w http.ResponseWriter, err error) {
+	if strings.Contains(err.Error(), "Duplicate entry") {
+		http.Error(w, "email already in use", http.StatusConflict)
+		return
+	}
+	http.Error(w, fmt.Sprintf("Error updating profile: %v", err), http.StatusInternalServerError)
+}
+
+// --- Handler for /students/{id}/profile (Replace) ---
+func (c *Config) StudentProfileHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodPut {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if _, err := c.DB.GetStudentByID(r.Context(), int32(id)); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	var body profile
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, "Invalid body", http.StatusBadRequest)
+		return
+	}
+	if err := body.validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := c.checkActsOn(r.Context(), by, int32(id)); err != nil {
+		actorError(w, err)
+		return
+	}
+	if err := c.DB.UpdateStudentProfile(r.Context(), body.studentParams(int32(id))); err != nil {
+		profileError(w, err)
+		return
+	}
+	c.getStudentById(w, r, int32(id))
+}
+
+// --- Handler for /tutors/{id}/profile (Replace) ---
+func (c *Config) TutorProfileHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodPut {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if _, err := c.DB.GetTutorByID(r.Context(), int32(id)); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	var body profile
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, "Invalid body", http.StatusBadRequest)
+		return
+	}
+	if err := body.validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := c.DB.UpdateTutorProfile(r.Context(), body.tutorParams(int32(id))); err != nil {
+		profileError(w, err)
+		return
+	}
+	c.getTutorById(w, r, int32(id))
+}
@@ -0,0 +1,369 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/wilgnert/webtutoria/internal/database"
+)
+
+const (
+	ruleAllSubjects   = "all_subjects"
+	ruleCategoryCount = "category_count"
+)
+
+// --- Handler for /classes/{id}/rules (List, Create) ---
+func (c *Config) ClassRulesHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		c.listClassRules(w, r, int32(id))
+	case http.MethodPost:
+		c.createClassRule(w, r, int32(id))
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// --- Handler for /classes/{id}/rules/{rule_id} (Delete) ---
+func (c *Config) ClassRuleByIDHandler(w http.ResponseWriter, r *http.Request) {
+	classID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	ruleID, err := strconv.Atoi(r.PathValue("rule_id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	rule, err := c.DB.GetProgressionRuleByID(r.Context(), int32(ruleID))
+	if err != nil || rule.ClassID != int32(classID) {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if err := c.DB.DeleteProgressionRule(r.Context(), rule.ID); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to delete progression rule: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// --- Handler for /students/{id}/class (History, Manual change) ---
+func (c *Config) StudentClassHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		c.listStudentClassHistory(w, r, int32(id))
+	case http.MethodPut:
+		c.setStudentClass(w, r, int32(id))
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// --- Handler for /students/{id}/progression (evaluate rules now) ---
+func (c *Config) StudentProgressionHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := c.DB.GetStudentByID(r.Context(), int32(id)); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if err := c.checkActsOn(r.Context(), by, int32(id)); err != nil {
+		actorError(w, err)
+		return
+	}
+	if err := c.evaluateClassProgression(r.Context(), int32(id), by); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to evaluate class progression: %v", err), http.StatusInternalServerError)
+		return
+	}
+	c.getStudentById(w, r, int32(id))
+}
+
+func (c *Config) listClassRules(w http.ResponseWriter, r *http.Request, classID int32) {
+	if _, err := c.DB.GetClassByID(r.Context(), classID); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	rules, err := c.DB.ListProgressionRulesByClass(r.Context(), classID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list progression rules: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, rules)
+}
+
+// createClassRule handles POST requests to /classes/{id}/rules. A
+// category_count rule names its category and the minimum number of completed
+// subjects of the class carrying it.
+func (c *Config) createClassRule(w http.ResponseWriter, r *http.Request, classID int32) {
+	if _, err := c.DB.GetClassByID(r.Context(), classID); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	var body struct {
+		Kind     string `json:"kind"`
+		Category string `json:"category"`
+		MinCount int32  `json:"min_count"`
+	}
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, "Invalid body", http.StatusBadRequest)
+		return
+	}
+	params := database.CreateProgressionRuleParams{ClassID: classID, Kind: body.Kind}
+	switch body.Kind {
+	case ruleAllSubjects:
+	case ruleCategoryCount:
+		if body.MinCount <= 0 {
+			http.Error(w, "min_count must be positive", http.StatusBadRequest)
+			return
+		}
+		cat, err := c.DB.GetCategoryByName(r.Context(), body.Category)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unknown category %q", body.Category), http.StatusBadRequest)
+			return
+		}
+		params.CategoryID = sql.NullInt32{Int32: cat.ID, Valid: true}
+		params.MinCount = body.MinCount
+	default:
+		http.Error(w, fmt.Sprintf("Unknown rule kind %q", body.Kind), http.StatusBadRequest)
+		return
+	}
+	result, err := c.DB.CreateProgressionRule(r.Context(), params)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to create progression rule: %v", err), http.StatusInternalServerError)
+		return
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to retrieve last insert ID: %v", err), http.StatusInternalServerError)
+		return
+	}
+	rule, err := c.DB.GetProgressionRuleByID(r.Context(), int32(id))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to retrieve new progression rule: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, rule)
+}
+
+func (c *Config) listStudentClassHistory(w http.ResponseWriter, r *http.Request, studentID int32) {
+	if _, err := c.DB.GetStudentByID(r.Context(), studentID); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	history, err := c.DB.ListStudentClassHistory(r.Context(), studentID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list class history: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, history)
+}
+
+// setStudentClass handles PUT requests to /students/{id}/class for manual
+// placements. An empty class removes the student from the ladder.
+func (c *Config) setStudentClass(w http.ResponseWriter, r *http.Request, studentID int32) {
+	var body struct {
+		Class  string `json:"class"`
+		Reason string `json:"reason"`
+	}
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, "Invalid body", http.StatusBadRequest)
+		return
+	}
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	stud, err := c.DB.GetStudentByID(r.Context(), studentID)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if err := c.checkActsOn(r.Context(), by, studentID); err != nil {
+		actorError(w, err)
+		return
+	}
+	to := sql.NullString{}
+	if code := normalizeClassCode(body.Class); code != "" {
+		class, err := c.DB.GetClassByCode(r.Context(), code)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unknown class %q", code), http.StatusBadRequest)
+			return
+		}
+		to = sql.NullString{String: class.Code, Valid: true}
+	}
+	if body.Reason == "" {
+		body.Reason = "manual change"
+	}
+	if err := c.changeStudentClass(r.Context(), stud, to, body.Reason, by); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to change student class: %v", err), http.StatusInternalServerError)
+		return
+	}
+	c.getStudentById(w, r, studentID)
+}
+
+// changeStudentClass moves a student, records the move in the class history
+// and emits a student.class_changed event.
+func (c *Config) changeStudentClass(ctx context.Context, stud database.Student, to sql.NullString, reason string, by actor) error {
+	if stud.Class == to {
+		return nil
+	}
+	err := c.DB.UpdateStudentClass(ctx, database.UpdateStudentClassParams{
+		Class: to,
+		ID:    stud.ID,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = c.DB.CreateStudentClassHistory(ctx, database.CreateStudentClassHistoryParams{
+		StudentID: stud.ID,
+		FromClass: stud.Class,
+		ToClass:   to,
+		Reason:    reason,
+		ChangedBy: by.Name,
+	})
+	if err != nil {
+		return err
+	}
+	return c.emitEvent(ctx, eventStudentClassChanged, stud.ID, map[string]any{
+		"student_id": stud.ID,
+		"from_class": nullStringPtr(stud.Class),
+		"to_class":   nullStringPtr(to),
+		"reason":     reason,
+		"changed_by": by.Name,
+	})
+}
+
+// evaluateClassProgression advances the student up the class ladder for as
+// long as the rules of their current class are met. Classes without rules
+// never advance automatically.
+func (c *Config) evaluateClassProgression(ctx context.Context, studentID int32, by actor) error {
+	classes, err := c.DB.ListClasses(ctx)
+	if err != nil {
+		return err
+	}
+	// Bounded by the ladder length so a misconfigured ladder cannot loop
+	for range classes {
+		stud, err := c.DB.GetStudentByID(ctx, studentID)
+		if err != nil {
+			return err
+		}
+		if !stud.Class.Valid {
+			return nil
+		}
+		class, err := c.DB.GetClassByCode(ctx, stud.Class.String)
+		if err != nil {
+			return err
+		}
+		met, err := c.classRulesMet(ctx, studentID, class)
+		if err != nil || !met {
+			return err
+		}
+		next, err := c.DB.GetNextClass(ctx, class.Position)
+		if err == sql.ErrNoRows {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		reason := fmt.Sprintf("completed the progression rules of class %s", class.Code)
+		err = c.changeStudentClass(ctx, stud, sql.NullString{String: next.Code, Valid: true}, reason, by)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Config) classRulesMet(ctx context.Context, studentID int32, class database.Class) (bool, error) {
+	rules, err := c.DB.ListProgressionRulesByClass(ctx, class.ID)
+	if err != nil || len(rules) == 0 {
+		return false, err
+	}
+	subjects, err := c.DB.ListSubjectsByClass(ctx, class.Code)
+	if err != nil {
+		return false, err
+	}
+	completions, err := c.DB.ListStudentSubjectCompletionsByStudent(ctx, studentID)
+	if err != nil {
+		return false, err
+	}
+	// Only passing completions count towards progression
+	passed := map[int32]bool{}
+	for _, comp := range completions {
+		if comp.Passed {
+			passed[comp.SubjectID] = true
+		}
+	}
+	for _, rule := range rules {
+		switch rule.Kind {
+		case ruleAllSubjects:
+			if len(subjects) == 0 {
+				return false, nil
+			}
+			for _, sub := range subjects {
+				if !passed[sub.ID] {
+					return false, nil
+				}
+			}
+		case ruleCategoryCount:
+			// subjects of sub-categories count towards their ancestors
+			tree, err := c.loadCategoryTree(ctx)
+			if err != nil {
+				return false, err
+			}
+			within, err := c.subjectsInCategory(ctx, tree, rule.CategoryID.Int32)
+			if err != nil {
+				return false, err
+			}
+			count := int32(0)
+			for _, sub := range subjects {
+				if passed[sub.ID] && within[sub.ID] {
+					count++
+				}
+			}
+			if count < rule.MinCount {
+				return false, nil
+			}
+		default:
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func nullStringPtr(n sql.NullString) *string {
+	if !n.Valid {
+		return nil
+	}
+	return &n.String
+}
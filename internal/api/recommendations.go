@@ -0,0 +1,279 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/wilgnert/webtutoria/internal/database"
+)
+
+const defaultRecommendations = 5
+
+type recommendation struct {
+	SubjectID int32  `json:"subject_id"`
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	Class     string `json:"class"`
+	// Share of the peers who passed the student's last subject and went on
+	// to pass this one straight after
+	NextShare float64 `json:"next_share"`
+	PeersNext int     `json:"peers_next"`
+	// Share of all peers who passed this subject
+	CompletionShare float64 `json:"completion_share"`
+	// Median number of subjects peers had passed before this one
+	TypicalPosition *float64 `json:"typical_position"`
+	InProgress      bool     `json:"in_progress"`
+	Reasons         []string `json:"reasons"`
+}
+
+type blockedSubject struct {
+	SubjectID  int32    `json:"subject_id"`
+	Code       string   `json:"code"`
+	Name       string   `json:"name"`
+	WaitingFor []string `json:"waiting_for"`
+}
+
+type recommendationReport struct {
+	StudentID       int32             `json:"student_id"`
+	LastPassed      *database.Subject `json:"last_passed"`
+	Peers           int               `json:"peers"`
+	PeersAfterLast  int               `json:"peers_after_last"`
+	Recommendations []recommendation  `json:"recommendations"`
+	Blocked         []blockedSubject  `json:"blocked"`
+}
+
+// passSequences orders every student's passed subjects by completion date;
+// a subject passed twice counts at its first pass.
+func passSequences(completions []database.Studentsubjectcompletion) map[int32][]int32 {
+	passes := []database.Studentsubjectcompletion{}
+	for _, comp := range completions {
+		if comp.Passed && comp.CompletedAt.Valid {
+			passes = append(passes, comp)
+		}
+	}
+	sort.SliceStable(passes, func(i, j int) bool {
+		a, b := passes[i].CompletedAt.Time, passes[j].CompletedAt.Time
+		if a.Equal(b) {
+			return passes[i].ID < passes[j].ID
+		}
+		return a.Before(b)
+	})
+	sequences := map[int32][]int32{}
+	seen := map[int32]map[int32]bool{}
+	for _, comp := range passes {
+		if seen[comp.StudentID] == nil {
+			seen[comp.StudentID] = map[int32]bool{}
+		}
+		if seen[comp.StudentID][comp.SubjectID] {
+			continue
+		}
+		seen[comp.StudentID][comp.SubjectID] = true
+		sequences[comp.StudentID] = append(sequences[comp.StudentID], comp.SubjectID)
+	}
+	return sequences
+}
+
+// pathBlocks maps each subject of the student's learning paths to the
+// earlier steps of those paths they have not passed yet; path order is the
+// only prerequisite the tree knows of.
+func (c *Config) pathBlocks(ctx context.Context, studentID int32, passed map[int32]bool) (map[int32][]int32, error) {
+	enrollments, err := c.DB.ListStudentLearningPaths(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	blocks := map[int32][]int32{}
+	for _, enrollment := range enrollments {
+		steps, err := c.DB.ListLearningPathSubjects(ctx, enrollment.PathID)
+		if err != nil {
+			return nil, err
+		}
+		var pending []int32
+		for _, step := range steps {
+			if len(pending) > 0 && !passed[step.SubjectID] {
+				blocks[step.SubjectID] = append(blocks[step.SubjectID], pending...)
+			}
+			if !passed[step.SubjectID] {
+				pending = append(pending, step.SubjectID)
+			}
+		}
+	}
+	return blocks, nil
+}
+
+// --- Handler for /students/{id}/recommendations ---
+// Ranks the subjects the student may take next by what peers did after the
+// student's last passed subject, then by how many peers passed them, then by
+// how close their usual place in peers' order is to the student's.
+// Subjects outside the student's class and paths are left out, and path
+// subjects whose earlier steps are not passed are listed as blocked.
+func (c *Config) StudentRecommendationsHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	limit := defaultRecommendations
+	if s := r.URL.Query().Get("limit"); s != "" {
+		limit, err = strconv.Atoi(s)
+		if err != nil || limit <= 0 || limit > 100 {
+			http.Error(w, "limit must be between 1 and 100", http.StatusBadRequest)
+			return
+		}
+	}
+	stud, err := c.DB.GetStudentByID(r.Context(), int32(id))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	report, err := c.recommendSubjects(r.Context(), stud)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to recommend subjects: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if len(report.Recommendations) > limit {
+		report.Recommendations = report.Recommendations[:limit]
+	}
+	respondWithJSON(w, http.StatusOK, report)
+}
+
+func (c *Config) recommendSubjects(ctx context.Context, stud database.Student) (recommendationReport, error) {
+	report := recommendationReport{
+		StudentID:       stud.ID,
+		Recommendations: []recommendation{},
+		Blocked:         []blockedSubject{},
+	}
+	candidates, err := c.outstandingSubjects(ctx, stud)
+	if err != nil {
+		return report, err
+	}
+	all, err := c.DB.ListSubjects(ctx)
+	if err != nil {
+		return report, err
+	}
+	subjects := map[int32]database.Subject{}
+	for _, sub := range all {
+		subjects[sub.ID] = sub
+	}
+	completions, err := c.DB.ListStudentSubjectCompletions(ctx)
+	if err != nil {
+		return report, err
+	}
+	statuses, err := c.DB.ListStudentSubjectStatusesByStudent(ctx, stud.ID)
+	if err != nil {
+		return report, err
+	}
+	inProgress := map[int32]bool{}
+	for _, s := range statuses {
+		if s.Status == subjectInProgress {
+			inProgress[s.SubjectID] = true
+		}
+	}
+
+	sequences := passSequences(completions)
+	own := sequences[stud.ID]
+	delete(sequences, stud.ID)
+	passed := map[int32]bool{}
+	for _, subjectID := range own {
+		passed[subjectID] = true
+	}
+	if len(own) > 0 {
+		last := subjects[own[len(own)-1]]
+		report.LastPassed = &last
+	}
+
+	// What peers passed right after the student's last subject, how many
+	// peers passed each subject and how many passes came before it
+	nextAfterLast := map[int32]int{}
+	passedBy := map[int32]int{}
+	positions := map[int32][]int{}
+	for _, seq := range sequences {
+		report.Peers++
+		for i, subjectID := range seq {
+			passedBy[subjectID]++
+			positions[subjectID] = append(positions[subjectID], i)
+			if report.LastPassed != nil && subjectID == report.LastPassed.ID && i+1 < len(seq) {
+				report.PeersAfterLast++
+				nextAfterLast[seq[i+1]]++
+			}
+		}
+	}
+
+	blocks, err := c.pathBlocks(ctx, stud.ID, passed)
+	if err != nil {
+		return report, err
+	}
+
+	for _, sub := range candidates {
+		if waiting := blocks[sub.ID]; len(waiting) > 0 {
+			blocked := blockedSubject{SubjectID: sub.ID, Code: sub.Code, Name: sub.Name, WaitingFor: []string{}}
+			for _, subjectID := range waiting {
+				blocked.WaitingFor = append(blocked.WaitingFor, subjects[subjectID].Code)
+			}
+			report.Blocked = append(report.Blocked, blocked)
+			continue
+		}
+		rec := recommendation{
+			SubjectID:  sub.ID,
+			Code:       sub.Code,
+			Name:       sub.Name,
+			Class:      sub.Class,
+			PeersNext:  nextAfterLast[sub.ID],
+			InProgress: inProgress[sub.ID],
+			Reasons:    []string{},
+		}
+		if report.PeersAfterLast > 0 {
+			rec.NextShare = float64(rec.PeersNext) / float64(report.PeersAfterLast)
+		}
+		if report.Peers > 0 {
+			rec.CompletionShare = float64(passedBy[sub.ID]) / float64(report.Peers)
+		}
+		if len(positions[sub.ID]) > 0 {
+			typical := median(positions[sub.ID])
+			rec.TypicalPosition = &typical
+		}
+
+		if rec.PeersNext > 0 {
+			rec.Reasons = append(rec.Reasons, fmt.Sprintf("%.0f%% of peers (%d of %d) who passed %s did this next",
+				rec.NextShare*100, rec.PeersNext, report.PeersAfterLast, report.LastPassed.Code))
+		}
+		if passedBy[sub.ID] > 0 {
+			rec.Reasons = append(rec.Reasons, fmt.Sprintf("passed by %.0f%% of peers, usually after %g other subjects",
+				rec.CompletionShare*100, *rec.TypicalPosition))
+		} else {
+			rec.Reasons = append(rec.Reasons, "no peer has passed this yet")
+		}
+		if rec.InProgress {
+			rec.Reasons = append(rec.Reasons, "already in progress")
+		}
+		report.Recommendations = append(report.Recommendations, rec)
+	}
+
+	distance := func(rec recommendation) float64 {
+		if rec.TypicalPosition == nil {
+			return math.Inf(1)
+		}
+		return math.Abs(*rec.TypicalPosition - float64(len(own)))
+	}
+	sort.SliceStable(report.Recommendations, func(i, j int) bool {
+		a, b := report.Recommendations[i], report.Recommendations[j]
+		if a.NextShare != b.NextShare {
+			return a.NextShare > b.NextShare
+		}
+		if a.CompletionShare != b.CompletionShare {
+			return a.CompletionShare > b.CompletionShare
+		}
+		if distance(a) != distance(b) {
+			return distance(a) < distance(b)
+		}
+		return a.Code < b.Code
+	})
+	return report, nil
+}
@@ -0,0 +1,339 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/wilgnert/webtutoria/internal/database"
+)
+
+type subjectRubric struct {
+	SubjectID    int32                 `json:"subject_id"`
+	PassingGrade *float64              `json:"passing_grade"`
+	Items        []database.Rubricitem `json:"items"`
+}
+
+type gradeBucket struct {
+	From  float64 `json:"from"`
+	To    float64 `json:"to"`
+	Count int     `json:"count"`
+}
+
+type gradeDistribution struct {
+	SubjectID    int32         `json:"subject_id"`
+	PassingGrade *float64      `json:"passing_grade"`
+	Completions  int           `json:"completions"`
+	Graded       int           `json:"graded"`
+	Passed       int           `json:"passed"`
+	Failed       int           `json:"failed"`
+	Average      *float64      `json:"average"`
+	Min          *float64      `json:"min"`
+	Max          *float64      `json:"max"`
+	Buckets      []gradeBucket `json:"buckets"`
+}
+
+// --- Handler for /subjects/{id}/rubric (Get, Replace) ---
+func (c *Config) SubjectRubricHandler(w http.ResponseWriter, r *http.Request) {
+	id_str := r.PathValue("id")
+	id, err := strconv.Atoi(id_str)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		c.getSubjectRubric(w, r, int32(id))
+	case http.MethodPut:
+		c.replaceSubjectRubric(w, r, int32(id))
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// --- Handler for /subjects/{id}/grades (grade distribution report) ---
+func (c *Config) SubjectGradesHandler(w http.ResponseWriter, r *http.Request) {
+	id_str := r.PathValue("id")
+	id, err := strconv.Atoi(id_str)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	c.getSubjectGradeDistribution(w, r, int32(id))
+}
+
+// --- Handler for /students-subjects/{id}/scores ---
+func (c *Config) CompletionScoresHandler(w http.ResponseWriter, r *http.Request) {
+	id_str := r.PathValue("id")
+	id, err := strconv.Atoi(id_str)
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if _, err := c.DB.GetStudentSubjectCompletionByID(r.Context(), int32(id)); err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "Student Subject Completion not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to get student subject completion: %v", err), http.StatusInternalServerError)
+		return
+	}
+	scores, err := c.DB.ListCompletionScoresByCompletion(r.Context(), int32(id))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list completion scores: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, scores)
+}
+
+func (c *Config) getSubjectRubric(w http.ResponseWriter, r *http.Request, id int32) {
+	sub, err := c.DB.GetSubjectByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	items, err := c.DB.ListRubricItemsBySubject(r.Context(), id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list rubric items: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, subjectRubric{
+		SubjectID:    sub.ID,
+		PassingGrade: nullFloatPtr(sub.PassingGrade),
+		Items:        items,
+	})
+}
+
+// replaceSubjectRubric handles PUT requests to /subjects/{id}/rubric.
+// Items are matched by name: existing ones are updated in place so their
+// scores survive, and items missing from the body are removed.
+func (c *Config) replaceSubjectRubric(w http.ResponseWriter, r *http.Request, id int32) {
+	if _, err := c.DB.GetSubjectByID(r.Context(), id); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	var body struct {
+		PassingGrade *float64 `json:"passing_grade"`
+		Items        []struct {
+			Name      string   `json:"name"`
+			Weight    *float64 `json:"weight"`
+			MaxPoints int32    `json:"max_points"`
+		} `json:"items"`
+	}
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, "Invalid body", http.StatusBadRequest)
+		return
+	}
+	if body.PassingGrade != nil && (*body.PassingGrade < 0 || *body.PassingGrade > 100) {
+		http.Error(w, "passing_grade must be between 0 and 100", http.StatusBadRequest)
+		return
+	}
+	keep := map[string]bool{}
+	for _, item := range body.Items {
+		if item.Name == "" || item.MaxPoints <= 0 || (item.Weight != nil && *item.Weight <= 0) {
+			http.Error(w, "rubric items need a name, a positive max_points and a positive weight", http.StatusBadRequest)
+			return
+		}
+		if keep[item.Name] {
+			http.Error(w, fmt.Sprintf("duplicate rubric item %q", item.Name), http.StatusBadRequest)
+			return
+		}
+		keep[item.Name] = true
+	}
+
+	// the rubric is replaced as a whole or not at all
+	err := c.inTx(r.Context(), func(q *database.Queries) error {
+		err := q.UpdateSubjectPassingGrade(r.Context(), database.UpdateSubjectPassingGradeParams{
+			PassingGrade: floatPtrToNull(body.PassingGrade),
+			ID:           id,
+		})
+		if err != nil {
+			return err
+		}
+		for i, item := range body.Items {
+			// items without a weight count once
+			weight := 1.0
+			if item.Weight != nil {
+				weight = *item.Weight
+			}
+			err := q.UpsertRubricItem(r.Context(), database.UpsertRubricItemParams{
+				SubjectID: id,
+				Name:      item.Name,
+				Weight:    weight,
+				MaxPoints: item.MaxPoints,
+				Position:  int32(i),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		existing, err := q.ListRubricItemsBySubject(r.Context(), id)
+		if err != nil {
+			return err
+		}
+		for _, item := range existing {
+			if keep[item.Name] {
+				continue
+			}
+			if err := q.DeleteRubricItem(r.Context(), item.ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to save rubric: %v", err), http.StatusInternalServerError)
+		return
+	}
+	c.getSubjectRubric(w, r, id)
+}
+
+func (c *Config) getSubjectGradeDistribution(w http.ResponseWriter, r *http.Request, id int32) {
+	sub, err := c.DB.GetSubjectByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	sc, err := c.scopeFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	completions, err := c.DB.ListStudentSubjectCompletionsBySubject(r.Context(), id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list completions for subject: %v", err), http.StatusInternalServerError)
+		return
+	}
+	scoped := []database.Studentsubjectcompletion{}
+	for _, comp := range completions {
+		if sc.includesCompletion(comp) {
+			scoped = append(scoped, comp)
+		}
+	}
+	respondWithJSON(w, http.StatusOK, buildGradeDistribution(sub, scoped))
+}
+
+// buildGradeDistribution summarises the graded completions of a subject in
+// ten buckets of ten points each.
+func buildGradeDistribution(sub database.Subject, completions []database.Studentsubjectcompletion) gradeDistribution {
+	dist := gradeDistribution{
+		SubjectID:    sub.ID,
+		PassingGrade: nullFloatPtr(sub.PassingGrade),
+		Completions:  len(completions),
+	}
+	for i := 0; i < 10; i++ {
+		dist.Buckets = append(dist.Buckets, gradeBucket{From: float64(i * 10), To: float64(i*10 + 10)})
+	}
+	var sum, lowest, highest float64
+	for _, comp := range completions {
+		if comp.Passed {
+			dist.Passed++
+		} else {
+			dist.Failed++
+		}
+		if !comp.Grade.Valid {
+			continue
+		}
+		g := comp.Grade.Float64
+		if dist.Graded == 0 || g < lowest {
+			lowest = g
+		}
+		if dist.Graded == 0 || g > highest {
+			highest = g
+		}
+		dist.Graded++
+		sum += g
+		bucket := int(math.Min(g, 99.999) / 10)
+		if bucket < 0 {
+			bucket = 0
+		}
+		dist.Buckets[bucket].Count++
+	}
+	if dist.Graded > 0 {
+		avg := sum / float64(dist.Graded)
+		dist.Average, dist.Min, dist.Max = &avg, &lowest, &highest
+	}
+	return dist
+}
+
+var errScoresRequired = errors.New("this subject is graded by rubric; scores are required")
+
+type completionScore struct {
+	RubricItemID int32   `json:"rubric_item_id"`
+	Points       float64 `json:"points"`
+}
+
+// gradeCompletion validates the given scores against a subject's rubric and
+// returns the weighted grade from 0 to 100. Rubric items without a score
+// count as zero points.
+func gradeCompletion(items []database.Rubricitem, scores []completionScore) (float64, error) {
+	byID := map[int32]database.Rubricitem{}
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+	points := map[int32]float64{}
+	for _, s := range scores {
+		item, ok := byID[s.RubricItemID]
+		if !ok {
+			return 0, fmt.Errorf("rubric item %d does not belong to this subject", s.RubricItemID)
+		}
+		if s.Points < 0 || s.Points > float64(item.MaxPoints) {
+			return 0, fmt.Errorf("points for %q must be between 0 and %d", item.Name, item.MaxPoints)
+		}
+		if _, dup := points[s.RubricItemID]; dup {
+			return 0, fmt.Errorf("rubric item %d scored more than once", s.RubricItemID)
+		}
+		points[s.RubricItemID] = s.Points
+	}
+	var total, weights float64
+	for _, item := range items {
+		total += item.Weight * points[item.ID] / float64(item.MaxPoints)
+		weights += item.Weight
+	}
+	if weights == 0 {
+		return 0, nil
+	}
+	return total / weights * 100, nil
+}
+
+// completionGrade applies the pass rule for a new completion. Subjects with a
+// rubric must be scored and pass on their passing_grade; subjects without one
+// are passed ungraded.
+func completionGrade(sub database.Subject, items []database.Rubricitem, scores []completionScore) (sql.NullFloat64, bool, error) {
+	if len(scores) == 0 {
+		if len(items) > 0 {
+			return sql.NullFloat64{}, false, errScoresRequired
+		}
+		return sql.NullFloat64{}, true, nil
+	}
+	g, err := gradeCompletion(items, scores)
+	if err != nil {
+		return sql.NullFloat64{}, false, err
+	}
+	return sql.NullFloat64{Float64: g, Valid: true}, !sub.PassingGrade.Valid || g >= sub.PassingGrade.Float64, nil
+}
+
+func nullFloatPtr(n sql.NullFloat64) *float64 {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Float64
+}
+
+func floatPtrToNull(f *float64) sql.NullFloat64 {
+	if f == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *f, Valid: true}
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/wilgnert/webtutoria/internal/database"
+)
+
+type studentExport struct {
+	ExportedAt    time.Time                           `json:"exported_at"`
+	Student       studentView                         `json:"student"`
+	StatusHistory []database.Studentstatustransition  `json:"status_history"`
+	ClassHistory  []database.Studentclasshistory      `json:"class_history"`
+	Tutors        []tutorHistoryEntry                 `json:"tutors"`
+	Completions   []database.Studentsubjectcompletion `json:"completions"`
+	LearningPaths []database.Studentlearningpath      `json:"learning_paths"`
+	Notes         []noteView                          `json:"notes"`
+}
+
+// --- Handler for /students/{id}/export ---
+// Everything recorded about a student in one document. Notes follow the same
+// visibility rules as GET /students/{id}/notes: the student view unless the
+// caller is a tutor or asks for ?audience=staff.
+func (c *Config) StudentExportHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := r.Context()
+	stud, err := c.DB.GetStudentByID(ctx, int32(id))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	viewer, err := c.noteViewerFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	export := studentExport{ExportedAt: time.Now(), Student: newStudentView(stud)}
+	if export.StatusHistory, err = c.DB.ListStudentStatusTransitions(ctx, stud.ID); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list status history: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if export.ClassHistory, err = c.DB.ListStudentClassHistory(ctx, stud.ID); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list class history: %v", err), http.StatusInternalServerError)
+		return
+	}
+	history, err := c.DB.ListStudentTutorHistory(ctx, stud.ID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list tutor history: %v", err), http.StatusInternalServerError)
+		return
+	}
+	export.Tutors = newTutorHistory(history)
+	if export.Completions, err = c.DB.ListStudentSubjectCompletionsByStudent(ctx, stud.ID); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list completions: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if export.LearningPaths, err = c.DB.ListStudentLearningPaths(ctx, stud.ID); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list learning paths: %v", err), http.StatusInternalServerError)
+		return
+	}
+	notes, err := c.DB.ListNotesByStudent(ctx, stud.ID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list notes: %v", err), http.StatusInternalServerError)
+		return
+	}
+	visible, err := c.visibleNotes(ctx, viewer, notes)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to check note visibility: %v", err), http.StatusInternalServerError)
+		return
+	}
+	export.Notes = newNoteViews(visible)
+	respondWithJSON(w, http.StatusOK, export)
+}
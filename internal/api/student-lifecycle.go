@@ -0,0 +1,187 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/wilgnert/webtutoria/internal/database"
+)
+
+const (
+	studentApplicant = "applicant"
+	studentActive    = "active"
+	studentPaused    = "paused"
+	studentGraduated = "graduated"
+	studentDropped   = "dropped"
+
+	eventStudentStatusChanged = "student.status_changed"
+)
+
+// studentTransitions lists the statuses a student may move to from each
+// status. Graduation is final; dropped students re-apply.
+var studentTransitions = map[string][]string{
+	studentApplicant: {studentActive, studentDropped},
+	studentActive:    {studentPaused, studentGraduated, studentDropped},
+	studentPaused:    {studentActive, studentDropped},
+	studentGraduated: {},
+	studentDropped:   {studentApplicant},
+}
+
+var errStatusChanged = errors.New("student status changed concurrently")
+
+func validStudentStatus(status string) bool {
+	_, ok := studentTransitions[status]
+	return ok
+}
+
+func canTransitionStudent(from, to string) bool {
+	for _, allowed := range studentTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// studentIsEngaged reports whether the student should show up for tutors and
+// in Discord role sync; paused and dropped students do not.
+func studentIsEngaged(status string) bool {
+	return status != studentPaused && status != studentDropped
+}
+
+// engagedStudents returns the ids of the students that are neither paused nor
+// dropped.
+func (c *Config) engagedStudents(ctx context.Context) (map[int32]bool, error) {
+	studs, err := c.DB.GetAllStudents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	engaged := map[int32]bool{}
+	for _, stud := range studs {
+		if studentIsEngaged(stud.Status) {
+			engaged[stud.ID] = true
+		}
+	}
+	return engaged, nil
+}
+
+// --- Handler for /students/{id}/status (History, Transition) ---
+func (c *Config) StudentStatusHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		c.listStudentStatusTransitions(w, r, int32(id))
+	case http.MethodPut:
+		c.transitionStudent(w, r, int32(id))
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (c *Config) listStudentStatusTransitions(w http.ResponseWriter, r *http.Request, studentID int32) {
+	if _, err := c.DB.GetStudentByID(r.Context(), studentID); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	transitions, err := c.DB.ListStudentStatusTransitions(r.Context(), studentID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list status transitions: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, transitions)
+}
+
+// transitionStudent handles PUT requests to /students/{id}/status. The move
+// must be allowed from the current status and needs a reason.
+func (c *Config) transitionStudent(w http.ResponseWriter, r *http.Request, studentID int32) {
+	var body struct {
+		Status string `json:"status"`
+		Reason string `json:"reason"`
+	}
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, "Invalid body", http.StatusBadRequest)
+		return
+	}
+	if !validStudentStatus(body.Status) {
+		http.Error(w, fmt.Sprintf("Unknown status %q", body.Status), http.StatusBadRequest)
+		return
+	}
+	if body.Reason == "" {
+		http.Error(w, "A reason is required to change a student's status", http.StatusBadRequest)
+		return
+	}
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	stud, err := c.DB.GetStudentByID(r.Context(), studentID)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if err := c.checkActsOn(r.Context(), by, studentID); err != nil {
+		actorError(w, err)
+		return
+	}
+	if !canTransitionStudent(stud.Status, body.Status) {
+		http.Error(w, fmt.Sprintf("Cannot move a student from %s to %s", stud.Status, body.Status), http.StatusConflict)
+		return
+	}
+	if err := c.setStudentStatus(r.Context(), stud, body.Status, body.Reason, by); err != nil {
+		if err == errStatusChanged {
+			http.Error(w, "The student's status changed in the meantime; reload and try again", http.StatusConflict)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to change student status: %v", err), http.StatusInternalServerError)
+		return
+	}
+	c.getStudentById(w, r, studentID)
+}
+
+// setStudentStatus moves the student without checking the transition,
+// records it and emits a student.status_changed event, all in one
+// transaction. It returns errStatusChanged when the student is no longer in
+// the status it was read with.
+func (c *Config) setStudentStatus(ctx context.Context, stud database.Student, to, reason string, by actor) error {
+	return c.inTx(ctx, func(q *database.Queries) error {
+		result, err := q.UpdateStudentStatus(ctx, database.UpdateStudentStatusParams{
+			ToStatus:   to,
+			ID:         stud.ID,
+			FromStatus: stud.Status,
+		})
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return errStatusChanged
+		}
+		_, err = q.CreateStudentStatusTransition(ctx, database.CreateStudentStatusTransitionParams{
+			StudentID:        stud.ID,
+			FromStatus:       stud.Status,
+			ToStatus:         to,
+			Reason:           reason,
+			ChangedByTutorID: by.TutorID,
+			ChangedBy:        by.Name,
+		})
+		if err != nil {
+			return err
+		}
+		return emitEventWith(ctx, q, eventStudentStatusChanged, stud.ID, map[string]any{
+			"student_id":  stud.ID,
+			"from_status": stud.Status,
+			"to_status":   to,
+			"reason":      reason,
+			"changed_by":  by.Name,
+		})
+	})
+}
@@ -0,0 +1,550 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/wilgnert/webtutoria/internal/database"
+)
+
+const (
+	subjectNotStarted = "not_started"
+	subjectInProgress = "in_progress"
+	subjectBlocked    = "blocked"
+	subjectCompleted  = "completed"
+)
+
+type durationStats struct {
+	Key                    string   `json:"key"`
+	TimedCompletions       int      `json:"timed_completions"`
+	AverageHoursToComplete *float64 `json:"average_hours_to_complete"`
+	MedianHoursToComplete  *float64 `json:"median_hours_to_complete"`
+	Sessions               int      `json:"sessions"`
+	TrackedHours           float64  `json:"tracked_hours"`
+	hoursToComplete        []float64
+}
+
+// --- Handler for /students-subjects/status (List, Set) ---
+func (c *Config) StudentSubjectStatusHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		c.listStudentSubjectStatuses(w, r)
+	case http.MethodPut:
+		c.setStudentSubjectStatus(w, r)
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// --- Handler for /students-subjects/stats (time-to-complete report) ---
+func (c *Config) StudentSubjectStatsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	c.getStudentSubjectDurationStats(w, r)
+}
+
+// --- Handler for /study-sessions (List, Start) ---
+func (c *Config) StudySessionsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		c.listStudySessions(w, r)
+	case http.MethodPost:
+		c.startStudySession(w, r)
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// --- Handler for /study-sessions/{id} (Get, Stop) ---
+func (c *Config) StudySessionByIDHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Invalid ID format", http.StatusBadRequest)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		c.getStudySession(w, r, int32(id))
+	case http.MethodPut:
+		c.stopStudySession(w, r, int32(id))
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// listStudentSubjectStatuses handles GET requests to /students-subjects/status.
+// With ?student_id= every subject is listed, including the ones the student
+// has not started yet.
+func (c *Config) listStudentSubjectStatuses(w http.ResponseWriter, r *http.Request) {
+	studentIDStr := r.URL.Query().Get("student_id")
+	subjectIDStr := r.URL.Query().Get("subject_id")
+	sc, err := c.scopeFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if studentIDStr != "" {
+		studentID, err := strconv.ParseInt(studentIDStr, 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid student_id format", http.StatusBadRequest)
+			return
+		}
+		statuses, err := c.studentSubjectStatuses(r.Context(), int32(studentID))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to list subject statuses for student: %v", err), http.StatusInternalServerError)
+			return
+		}
+		respondWithJSON(w, http.StatusOK, filterStatuses(statuses, sc))
+		return
+	}
+
+	var statuses []database.Studentsubjectstatus
+	if subjectIDStr != "" {
+		subjectID, err := strconv.ParseInt(subjectIDStr, 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid subject_id format", http.StatusBadRequest)
+			return
+		}
+		statuses, err = c.DB.ListStudentSubjectStatusesBySubject(r.Context(), int32(subjectID))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to list subject statuses for subject: %v", err), http.StatusInternalServerError)
+			return
+		}
+	} else {
+		statuses, err = c.DB.ListStudentSubjectStatuses(r.Context())
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to list subject statuses: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+	respondWithJSON(w, http.StatusOK, filterStatuses(statuses, sc))
+}
+
+func filterStatuses(statuses []database.Studentsubjectstatus, sc scope) []database.Studentsubjectstatus {
+	filtered := []database.Studentsubjectstatus{}
+	for _, st := range statuses {
+		if sc.hasStudent(st.StudentID) {
+			filtered = append(filtered, st)
+		}
+	}
+	return filtered
+}
+
+// setStudentSubjectStatus handles PUT requests to /students-subjects/status.
+// The completed state is owned by POST /students-subjects and cannot be set
+// or left from here.
+func (c *Config) setStudentSubjectStatus(w http.ResponseWriter, r *http.Request) {
+	var reqPayload struct {
+		StudentID     int32  `json:"student_id"`
+		SubjectID     int32  `json:"subject_id"`
+		Status        string `json:"status"`
+		BlockedReason string `json:"blocked_reason"`
+	}
+	if err := DecodeJSON(r.Body, &reqPayload); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	switch reqPayload.Status {
+	case subjectNotStarted, subjectInProgress, subjectBlocked:
+	case subjectCompleted:
+		http.Error(w, "Use POST /students-subjects to complete a subject", http.StatusBadRequest)
+		return
+	default:
+		http.Error(w, fmt.Sprintf("Unknown status %q", reqPayload.Status), http.StatusBadRequest)
+		return
+	}
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := c.checkActsOn(r.Context(), by, reqPayload.StudentID); err != nil {
+		actorError(w, err)
+		return
+	}
+	_, err = c.DB.GetStudentSubjectCompletionByStudentAndSubject(r.Context(), database.GetStudentSubjectCompletionByStudentAndSubjectParams{
+		StudentID: reqPayload.StudentID,
+		SubjectID: reqPayload.SubjectID,
+	})
+	if err == nil {
+		http.Error(w, "Student has already completed this subject", http.StatusConflict)
+		return
+	} else if err != sql.ErrNoRows {
+		http.Error(w, fmt.Sprintf("Failed to get student subject completion: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	startedAt := sql.NullTime{}
+	if reqPayload.Status != subjectNotStarted {
+		startedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	}
+	err = c.DB.UpsertStudentSubjectStatus(r.Context(), database.UpsertStudentSubjectStatusParams{
+		StudentID: reqPayload.StudentID,
+		SubjectID: reqPayload.SubjectID,
+		Status:    reqPayload.Status,
+		StartedAt: startedAt,
+		BlockedReason: sql.NullString{
+			String: reqPayload.BlockedReason,
+			Valid:  reqPayload.Status == subjectBlocked && len(reqPayload.BlockedReason) > 0,
+		},
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to set subject status: %v", err), http.StatusInternalServerError)
+		return
+	}
+	status, err := c.DB.GetStudentSubjectStatus(r.Context(), database.GetStudentSubjectStatusParams{
+		StudentID: reqPayload.StudentID,
+		SubjectID: reqPayload.SubjectID,
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to retrieve subject status: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, status)
+}
+
+// studentSubjectStatuses lists every subject for a student, filling the gaps
+// with not_started and forcing completed where a completion exists.
+func (c *Config) studentSubjectStatuses(ctx context.Context, studentID int32) ([]database.Studentsubjectstatus, error) {
+	subjects, err := c.DB.ListSubjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := c.DB.ListStudentSubjectStatusesByStudent(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	completions, err := c.DB.ListStudentSubjectCompletionsByStudent(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	bySubject := map[int32]database.Studentsubjectstatus{}
+	for _, s := range stored {
+		bySubject[s.SubjectID] = s
+	}
+	completed := map[int32]bool{}
+	for _, comp := range completions {
+		completed[comp.SubjectID] = true
+	}
+	statuses := []database.Studentsubjectstatus{}
+	for _, sub := range subjects {
+		s, ok := bySubject[sub.ID]
+		if !ok {
+			s = database.Studentsubjectstatus{StudentID: studentID, SubjectID: sub.ID, Status: subjectNotStarted}
+		}
+		if completed[sub.ID] {
+			s.Status = subjectCompleted
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses, nil
+}
+
+// startSubject moves a subject to in_progress unless the student already
+// started, blocked or completed it.
+func (c *Config) startSubject(ctx context.Context, studentID, subjectID int32) error {
+	status, err := c.DB.GetStudentSubjectStatus(ctx, database.GetStudentSubjectStatusParams{
+		StudentID: studentID,
+		SubjectID: subjectID,
+	})
+	if err == nil && status.Status != subjectNotStarted {
+		return nil
+	} else if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	return c.DB.UpsertStudentSubjectStatus(ctx, database.UpsertStudentSubjectStatusParams{
+		StudentID: studentID,
+		SubjectID: subjectID,
+		Status:    subjectInProgress,
+		StartedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	})
+}
+
+// setSubjectCompleted keeps the status row in line with the completion table.
+func setSubjectCompleted(ctx context.Context, q *database.Queries, studentID, subjectID int32, completed bool) error {
+	status := subjectCompleted
+	if !completed {
+		status = subjectInProgress
+	}
+	return q.UpsertStudentSubjectStatus(ctx, database.UpsertStudentSubjectStatusParams{
+		StudentID: studentID,
+		SubjectID: subjectID,
+		Status:    status,
+	})
+}
+
+// recordCompletion stores a completion with its rubric scores and marks the
+// subject completed. Both the completion endpoint and checklist
+// auto-completion go through it, inside their transaction.
+func recordCompletion(ctx context.Context, q *database.Queries, arg database.CreateStudentSubjectCompletionParams, scores []completionScore) (int32, error) {
+	result, err := q.CreateStudentSubjectCompletion(ctx, arg)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	for _, score := range scores {
+		_, err := q.CreateCompletionScore(ctx, database.CreateCompletionScoreParams{
+			CompletionID: int32(id),
+			RubricItemID: score.RubricItemID,
+			Points:       score.Points,
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	return int32(id), setSubjectCompleted(ctx, q, arg.StudentID, arg.SubjectID, true)
+}
+
+// completionRecorded moves the student up the class ladder and awards badges
+// once a completion has been committed. The completion stands either way, so
+// failures are logged rather than failing the request.
+func (c *Config) completionRecorded(ctx context.Context, studentID, completionID int32, by actor) {
+	if err := c.evaluateClassProgression(ctx, studentID, by); err != nil {
+		fmt.Printf("error evaluating class progression of student %d: %v\n", studentID, err)
+	}
+	if err := c.awardBadges(ctx, studentID, completionID); err != nil {
+		fmt.Printf("error awarding badges to student %d: %v\n", studentID, err)
+	}
+}
+
+// listStudySessions handles GET requests to /study-sessions.
+func (c *Config) listStudySessions(w http.ResponseWriter, r *http.Request) {
+	studentIDStr := r.URL.Query().Get("student_id")
+	subjectIDStr := r.URL.Query().Get("subject_id")
+
+	var sessions []database.Studysession
+	sc, err := c.scopeFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if studentIDStr != "" {
+		studentID, err := strconv.ParseInt(studentIDStr, 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid student_id format", http.StatusBadRequest)
+			return
+		}
+		sessions, err = c.DB.ListStudySessionsByStudent(r.Context(), int32(studentID))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to list study sessions for student: %v", err), http.StatusInternalServerError)
+			return
+		}
+	} else if subjectIDStr != "" {
+		subjectID, err := strconv.ParseInt(subjectIDStr, 10, 32)
+		if err != nil {
+			http.Error(w, "Invalid subject_id format", http.StatusBadRequest)
+			return
+		}
+		sessions, err = c.DB.ListStudySessionsBySubject(r.Context(), int32(subjectID))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to list study sessions for subject: %v", err), http.StatusInternalServerError)
+			return
+		}
+	} else {
+		sessions, err = c.DB.ListStudySessions(r.Context())
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to list study sessions: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+	filtered := []database.Studysession{}
+	for _, sess := range sessions {
+		if sc.covers(sess.StartedAt) && sc.inCohort(sess.StudentID) {
+			filtered = append(filtered, sess)
+		}
+	}
+	respondWithJSON(w, http.StatusOK, filtered)
+}
+
+// startStudySession handles POST requests to /study-sessions.
+func (c *Config) startStudySession(w http.ResponseWriter, r *http.Request) {
+	var reqPayload struct {
+		StudentID int32 `json:"student_id"`
+		SubjectID int32 `json:"subject_id"`
+	}
+	if err := DecodeJSON(r.Body, &reqPayload); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := c.checkActsOn(r.Context(), by, reqPayload.StudentID); err != nil {
+		actorError(w, err)
+		return
+	}
+	_, err = c.DB.GetOpenStudySession(r.Context(), database.GetOpenStudySessionParams{
+		StudentID: reqPayload.StudentID,
+		SubjectID: reqPayload.SubjectID,
+	})
+	if err == nil {
+		http.Error(w, "A study session is already running for this subject", http.StatusConflict)
+		return
+	} else if err != sql.ErrNoRows {
+		http.Error(w, fmt.Sprintf("Failed to get open study session: %v", err), http.StatusInternalServerError)
+		return
+	}
+	result, err := c.DB.CreateStudySession(r.Context(), database.CreateStudySessionParams{
+		StudentID: reqPayload.StudentID,
+		SubjectID: reqPayload.SubjectID,
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to start study session: %v", err), http.StatusInternalServerError)
+		return
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to retrieve new study session ID: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if err := c.startSubject(r.Context(), reqPayload.StudentID, reqPayload.SubjectID); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to set subject status: %v", err), http.StatusInternalServerError)
+		return
+	}
+	session, err := c.DB.GetStudySessionByID(r.Context(), int32(id))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to retrieve new study session: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, session)
+}
+
+func (c *Config) getStudySession(w http.ResponseWriter, r *http.Request, id int32) {
+	session, err := c.DB.GetStudySessionByID(r.Context(), id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "Study session not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to get study session: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, session)
+}
+
+// stopStudySession handles PUT requests to /study-sessions/{id}. Stopping a
+// session twice keeps the first stop time.
+func (c *Config) stopStudySession(w http.ResponseWriter, r *http.Request, id int32) {
+	session, err := c.DB.GetStudySessionByID(r.Context(), id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "Study session not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to get study session: %v", err), http.StatusInternalServerError)
+		return
+	}
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := c.checkActsOn(r.Context(), by, session.StudentID); err != nil {
+		actorError(w, err)
+		return
+	}
+	if err := c.DB.StopStudySession(r.Context(), id); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to stop study session: %v", err), http.StatusInternalServerError)
+		return
+	}
+	c.getStudySession(w, r, id)
+}
+
+// getStudentSubjectDurationStats handles GET requests to /students-subjects/stats.
+// Time to complete runs from started_at to completed_at; tracked hours add up
+// closed study sessions. Use ?group=class to aggregate per class instead of
+// per subject, and ?class= or ?subject_id= to narrow the report.
+func (c *Config) getStudentSubjectDurationStats(w http.ResponseWriter, r *http.Request) {
+	byClass := r.URL.Query().Get("group") == "class"
+	classFilter := r.URL.Query().Get("class")
+	subjectFilter := r.URL.Query().Get("subject_id")
+	sc, err := c.scopeFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	durations, err := c.DB.ListCompletionDurations(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list completion durations: %v", err), http.StatusInternalServerError)
+		return
+	}
+	sessions, err := c.DB.ListClosedStudySessions(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list study sessions: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	keyOf := func(subjectID int32, class string) (string, bool) {
+		if classFilter != "" && class != classFilter {
+			return "", false
+		}
+		if subjectFilter != "" && strconv.Itoa(int(subjectID)) != subjectFilter {
+			return "", false
+		}
+		if byClass {
+			return class, true
+		}
+		return strconv.Itoa(int(subjectID)), true
+	}
+	stats := map[string]*durationStats{}
+	get := func(key string) *durationStats {
+		if stats[key] == nil {
+			stats[key] = &durationStats{Key: key}
+		}
+		return stats[key]
+	}
+	for _, d := range durations {
+		key, ok := keyOf(d.SubjectID, d.Class)
+		if !ok || !sc.includesRecord(d.TermID, d.CompletedAt, d.StudentID) {
+			continue
+		}
+		s := get(key)
+		if d.StartedAt.Valid && d.CompletedAt.Valid && !d.CompletedAt.Time.Before(d.StartedAt.Time) {
+			s.hoursToComplete = append(s.hoursToComplete, d.CompletedAt.Time.Sub(d.StartedAt.Time).Hours())
+		}
+	}
+	for _, sess := range sessions {
+		key, ok := keyOf(sess.SubjectID, sess.Class)
+		if !ok || !sc.covers(sess.StartedAt) || !sc.inCohort(sess.StudentID) {
+			continue
+		}
+		s := get(key)
+		s.Sessions++
+		s.TrackedHours += sess.StoppedAt.Time.Sub(sess.StartedAt).Hours()
+	}
+
+	report := []durationStats{}
+	for _, s := range stats {
+		s.TimedCompletions = len(s.hoursToComplete)
+		if s.TimedCompletions > 0 {
+			sort.Float64s(s.hoursToComplete)
+			var sum float64
+			for _, h := range s.hoursToComplete {
+				sum += h
+			}
+			avg := sum / float64(s.TimedCompletions)
+			median := s.hoursToComplete[s.TimedCompletions/2]
+			if s.TimedCompletions%2 == 0 {
+				median = (s.hoursToComplete[s.TimedCompletions/2-1] + median) / 2
+			}
+			s.AverageHoursToComplete, s.MedianHoursToComplete = &avg, &median
+		}
+		report = append(report, *s)
+	}
+	sort.Slice(report, func(i, j int) bool { return report[i].Key < report[j].Key })
+	respondWithJSON(w, http.StatusOK, report)
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/wilgnert/webtutoria/internal/database"
 )
@@ -57,7 +59,11 @@ func (c *Config) listStudentSubjectCompletions(w http.ResponseWriter, r *http.Re
 	subjectIDStr := r.URL.Query().Get("subject_id")
 
 	var completions []database.Studentsubjectcompletion // Use sqlc generated type
-	var err error
+	sc, err := c.scopeFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	if studentIDStr != "" {
 		studentID, err := strconv.ParseInt(studentIDStr, 10, 32)
@@ -90,7 +96,13 @@ func (c *Config) listStudentSubjectCompletions(w http.ResponseWriter, r *http.Re
 		}
 	}
 
-	respondWithJSON(w, http.StatusOK, completions)
+	filtered := []database.Studentsubjectcompletion{}
+	for _, comp := range completions {
+		if sc.includesCompletion(comp) {
+			filtered = append(filtered, comp)
+		}
+	}
+	respondWithJSON(w, http.StatusOK, filtered)
 }
 
 // createStudentSubjectCompletion handles POST requests to /students-subjects
@@ -98,35 +110,101 @@ func (c *Config) createStudentSubjectCompletion(w http.ResponseWriter, r *http.R
 	var reqPayload struct {
 		StudentID  int32      `json:"student_id"`
 		SubjectID  int32      `json:"subject_id"`
+		Scores     []completionScore `json:"scores"`
+		CompletedAt *time.Time       `json:"completed_at"`
+		TermID      *int32           `json:"term_id"`
 	}
 
 	if err := DecodeJSON(r.Body, &reqPayload); err != nil {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := c.checkActsOn(r.Context(), by, reqPayload.StudentID); err != nil {
+		actorError(w, err)
+		return
+	}
+	completedAt := time.Now()
+	if reqPayload.CompletedAt != nil {
+		if err := c.checkCompletionDate(*reqPayload.CompletedAt); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		completedAt = *reqPayload.CompletedAt
+	}
 
-	var newCompletion database.Studentsubjectcompletion
-	var err error
-	// Let the database set the timestamp (NOW())
-	result, err := c.DB.CreateStudentSubjectCompletion(r.Context(), database.CreateStudentSubjectCompletionParams{
+	subject, err := c.DB.GetSubjectByID(r.Context(), reqPayload.SubjectID)
+	if err != nil {
+		http.Error(w, "Subject not found", http.StatusBadRequest)
+		return
+	}
+	unchecked, err := c.DB.CountUncheckedRequiredItems(r.Context(), database.CountUncheckedRequiredItemsParams{
 		StudentID: reqPayload.StudentID,
 		SubjectID: reqPayload.SubjectID,
 	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to check subject checklist: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if unchecked > 0 {
+		http.Error(w, fmt.Sprintf("%d required checklist items are not checked", unchecked), http.StatusConflict)
+		return
+	}
+	items, err := c.DB.ListRubricItemsBySubject(r.Context(), subject.ID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list rubric items: %v", err), http.StatusInternalServerError)
+		return
+	}
+	grade, passed, err := completionGrade(subject, items, reqPayload.Scores)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	revision, err := c.subjectRevisionAt(r.Context(), subject.ID, completedAt)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get subject revision: %v", err), http.StatusInternalServerError)
+		return
+	}
+	// completions without a term_id belong to the term of their date, if any
+	term, err := c.termFor(r.Context(), reqPayload.TermID, completedAt)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var newCompletion database.Studentsubjectcompletion
+	var id int32
+	// the completion, its scores and the subject status are stored together
+	err = c.inTx(r.Context(), func(q *database.Queries) error {
+		id, err = recordCompletion(r.Context(), q, database.CreateStudentSubjectCompletionParams{
+			StudentID:         reqPayload.StudentID,
+			SubjectID:         reqPayload.SubjectID,
+			CompletedAt:       sql.NullTime{Time: completedAt, Valid: true},
+			Grade:             grade,
+			Passed:            passed,
+			RecordedByTutorID: by.TutorID,
+			RecordedBy:        by.Name,
+			RevisionID:        revision,
+			TermID:            term,
+		}, reqPayload.Scores)
+		return err
+	})
 	if err != nil {
 		// Handle unique constraint violation specifically
-		if err.Error() == "pq: duplicate key value violates unique constraint \"studentsubjectcompletion_student_id_subject_id_key\"" {
+		if strings.Contains(err.Error(), "Duplicate entry") {
 			http.Error(w, "Student has already completed this subject", http.StatusConflict)
 			return
 		}
 		http.Error(w, fmt.Sprintf("Failed to create student subject completion: %v", err), http.StatusInternalServerError)
 		return
 	}
-	id, err := result.LastInsertId()
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to retrieve new completion ID: %v", err), http.StatusInternalServerError)
-		return
-	}
-	newCompletion, err = c.DB.GetStudentSubjectCompletionByID(r.Context(), int32(id))
+	c.completionRecorded(r.Context(), reqPayload.StudentID, id, by)
+	newCompletion, err = c.DB.GetStudentSubjectCompletionByID(r.Context(), id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			http.Error(w, "Newly created Student Subject Completion not found", http.StatusInternalServerError)
@@ -155,10 +233,33 @@ func (c *Config) getStudentSubjectCompletionByID(w http.ResponseWriter, r *http.
 
 // deleteStudentSubjectCompletion handles DELETE requests to /students-subjects/{id}
 func (c *Config) deleteStudentSubjectCompletion(w http.ResponseWriter, r *http.Request, id int32) {
-	err := c.DB.DeleteStudentSubjectCompletion(r.Context(), id)
+	completion, err := c.DB.GetStudentSubjectCompletionByID(r.Context(), id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "Student Subject Completion not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to get student subject completion: %v", err), http.StatusInternalServerError)
+		return
+	}
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := c.checkActsOn(r.Context(), by, completion.StudentID); err != nil {
+		actorError(w, err)
+		return
+	}
+	err = c.DB.DeleteStudentSubjectCompletion(r.Context(), id)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Failed to delete student subject completion: %v", err), http.StatusInternalServerError)
 		return
 	}
+	// The student worked on the subject, so it goes back to in progress
+	if err := setSubjectCompleted(r.Context(), c.DB, completion.StudentID, completion.SubjectID, false); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to set subject status: %v", err), http.StatusInternalServerError)
+		return
+	}
 	w.WriteHeader(http.StatusNoContent) // 204 No Content for successful deletion
 }
\ No newline at end of file
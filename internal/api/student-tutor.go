@@ -1,9 +1,13 @@
 package api
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/wilgnert/webtutoria/internal/database"
 )
@@ -20,9 +24,7 @@ func (c *Config) StudentTutorHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 func (c *Config) StudentTutorByIDHandler(w http.ResponseWriter, r *http.Request) {
-	// Extract the ID from the URL path (e.g., /student-tutors/123)
-	idStr := r.URL.Path[len("/student-tutors/"):]
-	id, err := strconv.ParseInt(idStr, 10, 64)
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
 	if err != nil {
 		http.Error(w, "Invalid ID", http.StatusBadRequest)
 		return
@@ -31,8 +33,10 @@ func (c *Config) StudentTutorByIDHandler(w http.ResponseWriter, r *http.Request)
 	switch r.Method {
 	case http.MethodGet:
 		c.getStudentTutorByID(w, r, id)
+	case http.MethodPut:
+		c.updateStudentTutorRole(w, r, id)
 	case http.MethodDelete:
-		c.deleteStudentTutor(w, r, id)
+		c.endStudentTutor(w, r, id)
 	default:
 		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
 	}
@@ -43,7 +47,11 @@ func (c *Config) listStudentTutors(w http.ResponseWriter, r *http.Request) {
 	tutorIDStr := r.URL.Query().Get("tutor_id")
 	// Example: Get all student tutors (you might want to add pagination or filtering)
 	var studentTutors []database.Studenttutor
-	var err error
+	sc, err := c.scopeFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	if tutorIDStr != "" {
 		tutor_id, err := strconv.ParseInt(tutorIDStr, 10, 32)
@@ -56,6 +64,13 @@ func (c *Config) listStudentTutors(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, fmt.Sprintf("Failed to list student tutors: %v", err), http.StatusInternalServerError)
 			return
 		}
+		// a substitute also sees the students of the tutors they cover
+		covered, err := c.coveredAssignments(r.Context(), int32(tutor_id))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to list covered students: %v", err), http.StatusInternalServerError)
+			return
+		}
+		studentTutors = append(studentTutors, covered...)
 	} else if studentIDStr != "" {
 		studentID, err := strconv.ParseInt(studentIDStr, 10, 32)
 		if err != nil {
@@ -74,13 +89,39 @@ func (c *Config) listStudentTutors(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
-	respondWithJSON(w, http.StatusOK, studentTutors)
+	// A tutor's own list leaves out paused and dropped students unless
+	// ?include_inactive=true
+	var engaged map[int32]bool
+	if tutorIDStr != "" && r.URL.Query().Get("include_inactive") != "true" {
+		engaged, err = c.engagedStudents(r.Context())
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to list students: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+	// Ended assignments are history and only listed with ?include_ended=true
+	includeEnded := r.URL.Query().Get("include_ended") == "true"
+	filtered := []database.Studenttutor{}
+	for _, st := range studentTutors {
+		if engaged != nil && !engaged[st.StudentID] {
+			continue
+		}
+		if st.EndedAt.Valid && !includeEnded {
+			continue
+		}
+		if sc.hasTerm(st.TermID) && sc.inCohort(st.StudentID) {
+			filtered = append(filtered, st)
+		}
+	}
+	respondWithJSON(w, http.StatusOK, filtered)
 }
 
 func (c *Config) createStudentTutor(w http.ResponseWriter, r *http.Request) {
 	var req struct {
-		StudentID int32 `json:"student_id"`
-		TutorID   int32 `json:"tutor_id"`
+		StudentID int32  `json:"student_id"`
+		TutorID   int32  `json:"tutor_id"`
+		TermID    *int32 `json:"term_id"`
+		Role      string `json:"role"`
 	}
 	if err := DecodeJSON(r.Body, &req); err != nil {
 		fmt.Println("Error decoding JSON:", err, r.Body)
@@ -88,17 +129,29 @@ func (c *Config) createStudentTutor(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := c.DB.CreateStudentTutor(r.Context(), database.CreateStudentTutorParams{
-		StudentID: req.StudentID,
-		TutorID:   req.TutorID,
-	})
+	if req.Role != "" && !validTutorRole(req.Role) {
+		http.Error(w, "role must be primary, secondary or observer", http.StatusBadRequest)
+		return
+	}
+
+	// assignments without a term_id belong to the current term, if any
+	term, err := c.termFor(r.Context(), req.TermID, time.Now())
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to create student tutor: %v", err), http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
-	id, err := result.LastInsertId()
+
+	id, err := c.assignTutor(r.Context(), req.StudentID, req.TutorID, term, req.Role)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to retrieve last insert ID: %v", err), http.StatusInternalServerError)
+		if errors.Is(err, errTutorFull) || errors.Is(err, errAlreadyAssigned) || errors.Is(err, errPrimaryTaken) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		if err == sql.ErrNoRows {
+			http.Error(w, "Tutor not found", http.StatusBadRequest)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to create student tutor: %v", err), http.StatusInternalServerError)
 		return
 	}
 	res, err := c.DB.GetStudentTutorByID(r.Context(), int32(id))
@@ -126,11 +179,114 @@ func (c *Config) getStudentTutorByID(w http.ResponseWriter, r *http.Request, id
 	respondWithJSON(w, http.StatusOK, studentTutor)
 }
 
-func (c *Config) deleteStudentTutor(w http.ResponseWriter, r *http.Request, id int64) {
-	err := c.DB.DeleteStudentTutorByID(r.Context(), int32(id))
+// endStudentTutor handles DELETE requests to /students-tutors/{id}. The
+// assignment is kept with its end date, an optional reason and the outgoing
+// tutor's handoff notes.
+func (c *Config) endStudentTutor(w http.ResponseWriter, r *http.Request, id int64) {
+	var req struct {
+		Reason       string `json:"reason"`
+		HandoffNotes string `json:"handoff_notes"`
+	}
+	if err := DecodeJSON(r.Body, &req); err != nil && err != io.EOF {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	studentTutor, err := c.DB.GetStudentTutorByID(r.Context(), int32(id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "Student Tutor not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to get student tutor: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if studentTutor.EndedAt.Valid {
+		http.Error(w, "Assignment has already ended", http.StatusConflict)
+		return
+	}
+	if err := c.checkActsOn(r.Context(), by, studentTutor.StudentID); err != nil {
+		actorError(w, err)
+		return
+	}
+	result, err := c.DB.EndStudentTutor(r.Context(), database.EndStudentTutorParams{
+		EndedAt:      sql.NullTime{Time: time.Now(), Valid: true},
+		EndReason:    optionalString(req.Reason),
+		HandoffNotes: optionalString(req.HandoffNotes),
+		EndedBy:      sql.NullString{String: by.Name, Valid: true},
+		ID:           int32(id),
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to end student tutor: %v", err), http.StatusInternalServerError)
+		return
+	}
+	// a concurrent request may have ended it since it was read
+	if n, err := result.RowsAffected(); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to end student tutor: %v", err), http.StatusInternalServerError)
+		return
+	} else if n == 0 {
+		http.Error(w, "Assignment has already ended", http.StatusConflict)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// tutorHistoryEntry is one assignment in a student's tutor history.
+type tutorHistoryEntry struct {
+	ID           int32      `json:"id"`
+	TutorID      int32      `json:"tutor_id"`
+	TutorName    string     `json:"tutor_name"`
+	Role         string     `json:"role"`
+	TermID       *int32     `json:"term_id"`
+	CreatedAt    time.Time  `json:"created_at"`
+	EndedAt      *time.Time `json:"ended_at"`
+	EndReason    *string    `json:"end_reason"`
+	HandoffNotes *string    `json:"handoff_notes"`
+	EndedBy      *string    `json:"ended_by"`
+}
+
+func newTutorHistory(rows []database.ListStudentTutorHistoryRow) []tutorHistoryEntry {
+	history := make([]tutorHistoryEntry, 0, len(rows))
+	for _, row := range rows {
+		history = append(history, tutorHistoryEntry{
+			ID:           row.ID,
+			TutorID:      row.TutorID,
+			TutorName:    row.TutorName,
+			Role:         row.Role,
+			TermID:       nullInt32Ptr(row.TermID),
+			CreatedAt:    row.CreatedAt,
+			EndedAt:      nullTimePtr(row.EndedAt),
+			EndReason:    nullStringPtr(row.EndReason),
+			HandoffNotes: nullStringPtr(row.HandoffNotes),
+			EndedBy:      nullStringPtr(row.EndedBy),
+		})
+	}
+	return history
+}
+
+// --- Handler for /students/{id}/tutor-history ---
+func (c *Config) StudentTutorHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to delete student tutor: %v", err), http.StatusInternalServerError)
+		http.Error(w, "Not found", http.StatusNotFound)
 		return
 	}
-	w.WriteHeader(http.StatusNoContent) // Successful deletion, no content to return
-}
\ No newline at end of file
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if _, err := c.DB.GetStudentByID(r.Context(), int32(id)); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	history, err := c.DB.ListStudentTutorHistory(r.Context(), int32(id))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list tutor history: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, newTutorHistory(history))
+}
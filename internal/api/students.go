@@ -1,9 +1,11 @@
 package api
 
 import (
+	"database/sql"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/wilgnert/webtutoria/internal/database"
 )
@@ -19,6 +21,16 @@ func (c *Config) StudentsHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 func (c *Config) listStudents(w http.ResponseWriter, r *http.Request) {
+	sc, err := c.scopeFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	status := r.URL.Query().Get("status")
+	if status != "" && !validStudentStatus(status) {
+		http.Error(w, fmt.Sprintf("Unknown status %q", status), http.StatusBadRequest)
+		return
+	}
 	q := r.URL.Query().Get("q")
 	if r.URL.Query().Has("q") {
 		if len(q) < 3 {
@@ -30,19 +42,39 @@ func (c *Config) listStudents(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "Not found", http.StatusNotFound)
 			return
 		}
-		respondWithJSON(w, 200, studs)
+		respondWithJSON(w, 200, newStudentViews(filterStudents(studs, sc, status)))
+	} else if status != "" {
+		studs, err := c.DB.GetAllStudentsByStatus(r.Context(), status)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Error getting students: %v", err), http.StatusInternalServerError)
+			return
+		}
+		respondWithJSON(w, 200, newStudentViews(filterStudents(studs, sc, status)))
 	} else {
 		studs, err := c.DB.GetAllStudents(r.Context())
 		if err != nil {
 			http.Error(w, fmt.Sprintf("Error getting students: %v", err), http.StatusInternalServerError)
 			return
 		}
-		respondWithJSON(w, 200, studs)
+		respondWithJSON(w, 200, newStudentViews(filterStudents(studs, sc, status)))
 	}
 }
+
+func filterStudents(studs []database.Student, sc scope, status string) []database.Student {
+	filtered := []database.Student{}
+	for _, stud := range studs {
+		if sc.hasStudent(stud.ID) && (status == "" || stud.Status == status) {
+			filtered = append(filtered, stud)
+		}
+	}
+	return filtered
+}
 func (c *Config) createStudent(w http.ResponseWriter, r *http.Request) {
 	type reqBody struct {
-		Name string `json:"name"`
+		Name   string `json:"name"`
+		Class  string `json:"class"`
+		Status string `json:"status"`
+		profile
 	}
 	var newReqBody reqBody
 	defer r.Body.Close()
@@ -50,8 +82,44 @@ func (c *Config) createStudent(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, fmt.Sprintf("Error decoding JSON: %v", err), http.StatusBadRequest)
 		return
 	}
-	result, err := c.DB.CreateStudent(r.Context(), newReqBody.Name)
+	// new students start as active unless they are still applying
+	if newReqBody.Status == "" {
+		newReqBody.Status = studentActive
+	}
+	if newReqBody.Status != studentActive && newReqBody.Status != studentApplicant {
+		http.Error(w, "status must be applicant or active", http.StatusBadRequest)
+		return
+	}
+	if err := newReqBody.profile.validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	class := sql.NullString{}
+	if code := normalizeClassCode(newReqBody.Class); code != "" {
+		if _, err := c.DB.GetClassByCode(r.Context(), code); err != nil {
+			http.Error(w, fmt.Sprintf("Unknown class %q", code), http.StatusBadRequest)
+			return
+		}
+		class = sql.NullString{String: code, Valid: true}
+	}
+	result, err := c.DB.CreateStudent(r.Context(), database.CreateStudentParams{
+		Name:          newReqBody.Name,
+		Class:         class,
+		Status:        newReqBody.Status,
+		Email:         optionalString(newReqBody.Email),
+		PreferredName: optionalString(newReqBody.PreferredName),
+		Pronouns:      optionalString(newReqBody.Pronouns),
+		Timezone:      optionalString(newReqBody.Timezone),
+		Locale:        optionalString(newReqBody.Locale),
+		EnrolledOn:    newReqBody.enrolledOn(),
+		Bio:           optionalString(newReqBody.Bio),
+		AvatarUrl:     optionalString(newReqBody.AvatarURL),
+	})
 	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			http.Error(w, "email already in use", http.StatusConflict)
+			return
+		}
 		http.Error(w, fmt.Sprintf("Error creating student: %v", err), http.StatusInternalServerError)
 		return
 	}
@@ -65,7 +133,7 @@ func (c *Config) createStudent(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, fmt.Sprintf("Error creating student: %v", err), http.StatusInternalServerError)
 		return
 	}
-	respondWithJSON(w, 200, stud)
+	respondWithJSON(w, 200, newStudentView(stud))
 }
 
 func (c *Config) StudentsByIdHandler(w http.ResponseWriter, r *http.Request) {
@@ -90,7 +158,7 @@ func (c *Config) getStudentById(w http.ResponseWriter, r *http.Request, id int32
 		http.Error(w, "Not found", http.StatusNotFound)
 		return
 	}
-	respondWithJSON(w, 200, stud)
+	respondWithJSON(w, 200, newStudentView(stud))
 }
 func (c *Config) updateStudentById(w http.ResponseWriter, r *http.Request, id int32) {
 	type reqBody struct {
@@ -102,7 +170,16 @@ func (c *Config) updateStudentById(w http.ResponseWriter, r *http.Request, id in
 		http.Error(w, fmt.Sprintf("Error decoding JSON: %v", err), http.StatusBadRequest)
 		return
 	}
-	_, err := c.DB.UpdateStudent(r.Context(), database.UpdateStudentParams{
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := c.checkActsOn(r.Context(), by, id); err != nil {
+		actorError(w, err)
+		return
+	}
+	_, err = c.DB.UpdateStudent(r.Context(), database.UpdateStudentParams{
 		ID:   id,
 		Name: newReqBody.Name,
 	})
@@ -115,5 +192,5 @@ func (c *Config) updateStudentById(w http.ResponseWriter, r *http.Request, id in
 		http.Error(w, fmt.Sprintf("Error updating student: %v", err), http.StatusInternalServerError)
 		return
 	}
-	respondWithJSON(w, 200, stud)
+	respondWithJSON(w, 200, newStudentView(stud))
 }
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/wilgnert/webtutoria/internal/database"
+)
+
+type subjectChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+type subjectRevisionView struct {
+	ID            int32           `json:"id"`
+	Revision      int32           `json:"revision"`
+	Code          string          `json:"code"`
+	Name          string          `json:"name"`
+	Description   string          `json:"description"`
+	Class         string          `json:"class"`
+	EffectiveFrom time.Time       `json:"effective_from"`
+	ChangedBy     string          `json:"changed_by"`
+	Changes       []subjectChange `json:"changes"`
+}
+
+// --- Handler for /subjects/{id}/history (revisions with their changes) ---
+func (c *Config) SubjectHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if _, err := c.DB.GetSubjectByID(r.Context(), int32(id)); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	revisions, err := c.DB.ListSubjectRevisions(r.Context(), int32(id))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list subject revisions: %v", err), http.StatusInternalServerError)
+		return
+	}
+	history := []subjectRevisionView{}
+	for i, rev := range revisions {
+		view := subjectRevisionView{
+			ID:            rev.ID,
+			Revision:      rev.Revision,
+			Code:          rev.Code,
+			Name:          rev.Name,
+			Description:   rev.Description.String,
+			Class:         rev.Class,
+			EffectiveFrom: rev.EffectiveFrom,
+			ChangedBy:     rev.ChangedBy,
+			Changes:       []subjectChange{},
+		}
+		if i > 0 {
+			view.Changes = subjectRevisionChanges(revisions[i-1], rev)
+		}
+		history = append(history, view)
+	}
+	respondWithJSON(w, http.StatusOK, history)
+}
+
+func subjectRevisionChanges(prev, cur database.Subjectrevision) []subjectChange {
+	changes := []subjectChange{}
+	add := func(field, old, new string) {
+		if old != new {
+			changes = append(changes, subjectChange{Field: field, Old: old, New: new})
+		}
+	}
+	add("code", prev.Code, cur.Code)
+	add("name", prev.Name, cur.Name)
+	add("description", prev.Description.String, cur.Description.String)
+	add("class", prev.Class, cur.Class)
+	return changes
+}
+
+// recordSubjectRevision snapshots the subject as a new revision effective
+// from the given time. Nothing is recorded when the versioned fields did not
+// change since the latest revision. It runs in the transaction that changed
+// the subject, so a subject never changes without its history.
+func recordSubjectRevision(ctx context.Context, q *database.Queries, sub database.Subject, effectiveFrom time.Time, by actor) error {
+	number := int32(1)
+	latest, err := q.GetLatestSubjectRevision(ctx, sub.ID)
+	if err == nil {
+		if latest.Code == sub.Code && latest.Name == sub.Name && latest.Description == sub.Description && latest.Class == sub.Class {
+			return nil
+		}
+		number = latest.Revision + 1
+	} else if err != sql.ErrNoRows {
+		return err
+	}
+	_, err = q.CreateSubjectRevision(ctx, database.CreateSubjectRevisionParams{
+		SubjectID:     sub.ID,
+		Revision:      number,
+		Code:          sub.Code,
+		Name:          sub.Name,
+		Description:   sub.Description,
+		Class:         sub.Class,
+		EffectiveFrom: effectiveFrom,
+		ChangedBy:     by.Name,
+	})
+	return err
+}
+
+// checkRevisionDate rejects effective dates in the future or before the
+// latest revision, so revisions stay in order.
+func (c *Config) checkRevisionDate(ctx context.Context, subjectID int32, effectiveFrom time.Time) error {
+	if effectiveFrom.After(time.Now()) {
+		return fmt.Errorf("effective_from cannot be in the future")
+	}
+	latest, err := c.DB.GetLatestSubjectRevision(ctx, subjectID)
+	if err == sql.ErrNoRows {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if effectiveFrom.Before(latest.EffectiveFrom) {
+		return fmt.Errorf("effective_from cannot be before revision %d (%s)", latest.Revision, latest.EffectiveFrom.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// subjectRevisionAt returns the revision of the subject that was current at
+// the given time. Completions dated before the first revision are pinned to
+// it.
+func (c *Config) subjectRevisionAt(ctx context.Context, subjectID int32, at time.Time) (sql.NullInt32, error) {
+	rev, err := c.DB.GetSubjectRevisionAt(ctx, database.GetSubjectRevisionAtParams{
+		SubjectID:     subjectID,
+		EffectiveFrom: at,
+	})
+	if err == nil {
+		return sql.NullInt32{Int32: rev.ID, Valid: true}, nil
+	} else if err != sql.ErrNoRows {
+		return sql.NullInt32{}, err
+	}
+	revisions, err := c.DB.ListSubjectRevisions(ctx, subjectID)
+	if err != nil || len(revisions) == 0 {
+		return sql.NullInt32{}, err
+	}
+	return sql.NullInt32{Int32: revisions[0].ID, Valid: true}, nil
+}
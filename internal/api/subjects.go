@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/wilgnert/webtutoria/internal/database"
 )
@@ -33,15 +34,38 @@ func (c *Config) createSubject(w http.ResponseWriter, r * http.Request) {
 		http.Error(w, "Invalid body", http.StatusBadRequest)
 		return
 	}
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	class, ok := c.lookupClass(w, r, body.Class)
+	if !ok {
+		return
+	}
 
-	result, err := c.DB.CreateSubject(r.Context(), database.CreateSubjectParams{
-		Code: body.Code,
-		Name: body.Name,
-		Description: sql.NullString{
-			String: body.Description,
-			Valid: len(body.Description) > 0,
-		},
-		Class: body.Class,
+	var sub database.Subject
+	err = c.inTx(r.Context(), func(q *database.Queries) error {
+		result, err := q.CreateSubject(r.Context(), database.CreateSubjectParams{
+			Code: body.Code,
+			Name: body.Name,
+			Description: sql.NullString{
+				String: body.Description,
+				Valid: len(body.Description) > 0,
+			},
+			Class: class.Code,
+		})
+		if err != nil {
+			return err
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return err
+		}
+		if sub, err = q.GetSubjectByID(r.Context(), int32(id)); err != nil {
+			return err
+		}
+		return recordSubjectRevision(r.Context(), q, sub, time.Now(), by)
 	})
 	if err != nil {
 		if strings.Contains(err.Error(), "subjects_code_key") {
@@ -51,16 +75,6 @@ func (c *Config) createSubject(w http.ResponseWriter, r * http.Request) {
 		http.Error(w, fmt.Sprintf("Internal server error while creating subject: %v", err), http.StatusInternalServerError)
 		return
 	}
-	id, err := result.LastInsertId()
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Internal server error while getting last insert id: %v", err), http.StatusInternalServerError)
-		return
-	}
-	sub, err := c.DB.GetSubjectByID(r.Context(), int32(id))
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Internal server error while getting subject by id: %v", err), http.StatusInternalServerError)
-		return
-	}
 	
 	for _, cat := range body.Categories {
 		var some database.Category
@@ -93,17 +107,49 @@ func (c *Config) listAllSubjects(w http.ResponseWriter, r * http.Request) {
 	var subjects []database.Subject
 	var err error
 	if r.URL.Query().Has("class") {
-		subjects, err = c.DB.ListSubjectsByClass(r.Context(), r.URL.Query().Get("class"))
+		subjects, err = c.DB.ListSubjectsByClass(r.Context(), normalizeClassCode(r.URL.Query().Get("class")))
 	} else {
 		subjects, err = c.DB.ListSubjects(r.Context())
 	}
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Not found: %v", err), http.StatusNotFound)
 	}
+	// filtering by a category includes the subjects of its descendants
+	if r.URL.Query().Has("category") {
+		subjects, err = c.filterSubjectsByCategory(r.Context(), subjects, r.URL.Query().Get("category"))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Error filtering subjects by category: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
 
 	respondWithJSON(w, http.StatusOK, c.populateCategoriesSlice(r.Context(), subjects))
 }
 
+func (c *Config) filterSubjectsByCategory(ctx context.Context, subjects []database.Subject, name string) ([]database.Subject, error) {
+	filtered := []database.Subject{}
+	cat, err := c.DB.GetCategoryByName(ctx, name)
+	if err == sql.ErrNoRows {
+		return filtered, nil
+	} else if err != nil {
+		return nil, err
+	}
+	tree, err := c.loadCategoryTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	within, err := c.subjectsInCategory(ctx, tree, cat.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range subjects {
+		if within[s.ID] {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered, nil
+}
+
 type subjectsWithCategories struct{
 	ID 					int32          `json:"id"`
 	Code        string         `json:"code"`
@@ -111,6 +157,8 @@ type subjectsWithCategories struct{
 	Description string         `json:"description"`
 	Class       string         `json:"class"`
 	Categories  []string 			 `json:"categories"`
+	PassingGrade *float64      `json:"passing_grade"`
+	AutoComplete bool          `json:"auto_complete"`
 }
 
 func (c *Config) populateCategoriesSlice(ctx context.Context, subjects []database.Subject) []subjectsWithCategories {
@@ -133,6 +181,8 @@ func (c *Config) populateCategoriesOne(ctx context.Context, subject database.Sub
 		Description: subject.Description.String,
 		Class: subject.Class,
 		Categories: cats,
+		PassingGrade: nullFloatPtr(subject.PassingGrade),
+		AutoComplete: subject.AutoComplete,
 	}
 }
 
@@ -173,20 +223,49 @@ func (c *Config) updateSubjectById(w http.ResponseWriter, r * http.Request, id i
 		Description string         `json:"description"`
 		Class       string         `json:"class"`
 		Categories  []string 			 `json:"categories"`
+		EffectiveFrom *time.Time   `json:"effective_from"`
 	}
 	if err := DecodeJSON(r.Body, &body); err != nil {
 		http.Error(w, "Invalid body", http.StatusBadRequest)
 		return
 	}
-	_, err = c.DB.UpdateSubject(r.Context(), database.UpdateSubjectParams{
-		ID: sub.ID,
-		Code: body.Code,
-		Name: body.Name,
-		Description: sql.NullString{
-			String: body.Description,
-			Valid: len(body.Description) > 0,
-		},
-		Class: body.Class,
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// changes take effect now unless the body backdates them
+	effectiveFrom := time.Now()
+	if body.EffectiveFrom != nil {
+		effectiveFrom = *body.EffectiveFrom
+	}
+	if err := c.checkRevisionDate(r.Context(), id, effectiveFrom); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	class, ok := c.lookupClass(w, r, body.Class)
+	if !ok {
+		return
+	}
+	err = c.inTx(r.Context(), func(q *database.Queries) error {
+		_, err := q.UpdateSubject(r.Context(), database.UpdateSubjectParams{
+			ID: sub.ID,
+			Code: body.Code,
+			Name: body.Name,
+			Description: sql.NullString{
+				String: body.Description,
+				Valid: len(body.Description) > 0,
+			},
+			Class: class.Code,
+		})
+		if err != nil {
+			return err
+		}
+		updated, err := q.GetSubjectByID(r.Context(), id)
+		if err != nil {
+			return err
+		}
+		return recordSubjectRevision(r.Context(), q, updated, effectiveFrom, by)
 	})
 	if err != nil {
 		if strings.Contains(err.Error(), "subjects_code_key") {
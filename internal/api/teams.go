@@ -0,0 +1,255 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/wilgnert/webtutoria/internal/database"
+)
+
+const defaultInactiveDays = 14
+
+var errSupervisorCycle = errors.New("supervisor is in the tutor's own team")
+
+type teamMemberReport struct {
+	TutorID             int32   `json:"tutor_id"`
+	Name                string  `json:"name"`
+	SupervisorID        *int32  `json:"supervisor_id"`
+	Students            int     `json:"students"`
+	CompletionsThisWeek int     `json:"completions_this_week"`
+	InactiveStudents    int     `json:"inactive_students"`
+	InactiveStudentIDs  []int32 `json:"inactive_student_ids"`
+}
+
+// --- Handler for /tutors/{id}/supervisor (Set) ---
+func (c *Config) TutorSupervisorHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodPut {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	c.setTutorSupervisor(w, r, int32(id))
+}
+
+// --- Handler for /tutors/{id}/team-report ---
+func (c *Config) TutorTeamReportHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	c.teamReport(w, r, int32(id))
+}
+
+// setTutorSupervisor handles PUT requests to /tutors/{id}/supervisor. A null
+// supervisor_id removes the tutor from their team.
+func (c *Config) setTutorSupervisor(w http.ResponseWriter, r *http.Request, tutorID int32) {
+	var body struct {
+		SupervisorID *int32 `json:"supervisor_id"`
+	}
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, "Invalid body", http.StatusBadRequest)
+		return
+	}
+	if _, err := c.DB.GetTutorByID(r.Context(), tutorID); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	supervisor := sql.NullInt32{}
+	if body.SupervisorID != nil {
+		if _, err := c.DB.GetTutorByID(r.Context(), *body.SupervisorID); err != nil {
+			http.Error(w, "Supervisor not found", http.StatusBadRequest)
+			return
+		}
+		supervisor = sql.NullInt32{Int32: *body.SupervisorID, Valid: true}
+	}
+	// The tutors stay locked until the update, so two concurrent changes
+	// cannot close a cycle between them
+	err := c.inTx(r.Context(), func(q *database.Queries) error {
+		if supervisor.Valid {
+			links, err := q.LockTutorSupervisors(r.Context())
+			if err != nil {
+				return err
+			}
+			reports := map[int32][]int32{}
+			for _, l := range links {
+				if l.SupervisorID.Valid {
+					reports[l.SupervisorID.Int32] = append(reports[l.SupervisorID.Int32], l.ID)
+				}
+			}
+			if supervisor.Int32 == tutorID || teamBelow(reports, tutorID)[supervisor.Int32] {
+				return errSupervisorCycle
+			}
+		}
+		return q.UpdateTutorSupervisor(r.Context(), database.UpdateTutorSupervisorParams{
+			SupervisorID: supervisor,
+			ID:           tutorID,
+		})
+	})
+	if err == errSupervisorCycle {
+		http.Error(w, "A tutor cannot be supervised by themselves or their own team", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to set supervisor: %v", err), http.StatusInternalServerError)
+		return
+	}
+	c.getTutorById(w, r, tutorID)
+}
+
+// teamOf returns every tutor below the supervisor, directly or through
+// other head tutors.
+func (c *Config) teamOf(ctx context.Context, supervisorID int32) (map[int32]bool, error) {
+	tutors, err := c.DB.GetAllTutors(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reports := map[int32][]int32{}
+	for _, t := range tutors {
+		if t.SupervisorID.Valid {
+			reports[t.SupervisorID.Int32] = append(reports[t.SupervisorID.Int32], t.ID)
+		}
+	}
+	return teamBelow(reports, supervisorID), nil
+}
+
+// teamBelow walks the direct reports of every tutor down from the supervisor.
+func teamBelow(reports map[int32][]int32, supervisorID int32) map[int32]bool {
+	team := map[int32]bool{}
+	queue := []int32{supervisorID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, member := range reports[id] {
+			if !team[member] {
+				team[member] = true
+				queue = append(queue, member)
+			}
+		}
+	}
+	return team
+}
+
+// teamReport handles GET requests to /tutors/{id}/team-report. For every
+// tutor in the team it counts their students, the completions those students
+// recorded since Monday and the engaged students with no completion or study
+// session in the last ?inactive_days= days (14 by default). ?term_id and
+// ?cohort_id narrow it to the assignments and completions of the scope.
+func (c *Config) teamReport(w http.ResponseWriter, r *http.Request, supervisorID int32) {
+	supervisor, err := c.DB.GetTutorByID(r.Context(), supervisorID)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	inactiveDays := defaultInactiveDays
+	if v := r.URL.Query().Get("inactive_days"); v != "" {
+		inactiveDays, err = strconv.Atoi(v)
+		if err != nil || inactiveDays <= 0 {
+			http.Error(w, "Invalid inactive_days", http.StatusBadRequest)
+			return
+		}
+	}
+	sc, err := c.scopeFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	team, err := c.teamOf(r.Context(), supervisorID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to load team: %v", err), http.StatusInternalServerError)
+		return
+	}
+	engaged, err := c.engagedStudents(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list students: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	now := time.Now()
+	year, month, day := now.Date()
+	weekStart := time.Date(year, month, day-(int(now.Weekday())+6)%7, 0, 0, 0, 0, now.Location())
+	activeSince := now.AddDate(0, 0, -inactiveDays)
+
+	completions, err := c.DB.ListStudentSubjectCompletions(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list completions: %v", err), http.StatusInternalServerError)
+		return
+	}
+	weekly := map[int32]int{}
+	lastActive := map[int32]time.Time{}
+	for _, comp := range completions {
+		if !comp.CompletedAt.Valid {
+			continue
+		}
+		if !comp.CompletedAt.Time.Before(weekStart) && sc.includesCompletion(comp) {
+			weekly[comp.StudentID]++
+		}
+		if comp.CompletedAt.Time.After(lastActive[comp.StudentID]) {
+			lastActive[comp.StudentID] = comp.CompletedAt.Time
+		}
+	}
+	sessions, err := c.DB.ListStudySessions(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list study sessions: %v", err), http.StatusInternalServerError)
+		return
+	}
+	for _, s := range sessions {
+		if s.StartedAt.After(lastActive[s.StudentID]) {
+			lastActive[s.StudentID] = s.StartedAt
+		}
+	}
+
+	tutors, err := c.DB.GetAllTutors(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list tutors: %v", err), http.StatusInternalServerError)
+		return
+	}
+	members := []teamMemberReport{}
+	for _, t := range tutors {
+		if !team[t.ID] {
+			continue
+		}
+		row := teamMemberReport{
+			TutorID:            t.ID,
+			Name:               t.Name,
+			SupervisorID:       nullInt32Ptr(t.SupervisorID),
+			InactiveStudentIDs: []int32{},
+		}
+		assignments, err := c.DB.ListStudentTutorsByTutor(r.Context(), t.ID)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to list tutor students: %v", err), http.StatusInternalServerError)
+			return
+		}
+		for _, st := range assignments {
+			if st.EndedAt.Valid || !sc.hasTerm(st.TermID) || !sc.inCohort(st.StudentID) {
+				continue
+			}
+			row.Students++
+			row.CompletionsThisWeek += weekly[st.StudentID]
+			if engaged[st.StudentID] && lastActive[st.StudentID].Before(activeSince) {
+				row.InactiveStudents++
+				row.InactiveStudentIDs = append(row.InactiveStudentIDs, st.StudentID)
+			}
+		}
+		members = append(members, row)
+	}
+	respondWithJSON(w, http.StatusOK, map[string]any{
+		"supervisor_id": supervisor.ID,
+		"name":          supervisor.Name,
+		"week_start":    weekStart,
+		"inactive_days": inactiveDays,
+		"team":          members,
+	})
+}
@@ -0,0 +1,525 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wilgnert/webtutoria/internal/database"
+)
+
+const termDateLayout = "2006-01-02"
+
+// scope narrows list and report endpoints to a term and/or a cohort, given
+// as ?term_id= and ?cohort_id=. Records tagged with a term are matched on the
+// tag, dated records on the term's date range and everything else on the
+// students enrolled in the term's cohorts.
+//
+// Every endpoint that lists students' records or reports on them takes the
+// scope. Catalogs (/subjects, /classes, /categories, /tutors, /badges,
+// /tutor-discords) are not term-bound and ignore it, as does the /events
+// outbox, whose after/limit cursor would skip events if pages were filtered.
+type scope struct {
+	term           *database.Term
+	termStudents   map[int32]bool
+	cohortStudents map[int32]bool
+}
+
+func (c *Config) scopeFromRequest(r *http.Request) (scope, error) {
+	var s scope
+	query := r.URL.Query()
+	if query.Get("term_id") != "" {
+		termID, err := strconv.Atoi(query.Get("term_id"))
+		if err != nil {
+			return s, fmt.Errorf("Invalid term_id format")
+		}
+		term, err := c.DB.GetTermByID(r.Context(), int32(termID))
+		if err != nil {
+			return s, fmt.Errorf("Unknown term %d", termID)
+		}
+		ids, err := c.DB.ListStudentIDsByTerm(r.Context(), term.ID)
+		if err != nil {
+			return s, err
+		}
+		s.term = &term
+		s.termStudents = map[int32]bool{}
+		for _, id := range ids {
+			s.termStudents[id] = true
+		}
+	}
+	if query.Get("cohort_id") != "" {
+		cohortID, err := strconv.Atoi(query.Get("cohort_id"))
+		if err != nil {
+			return s, fmt.Errorf("Invalid cohort_id format")
+		}
+		if _, err := c.DB.GetCohortByID(r.Context(), int32(cohortID)); err != nil {
+			return s, fmt.Errorf("Unknown cohort %d", cohortID)
+		}
+		members, err := c.DB.ListCohortStudents(r.Context(), int32(cohortID))
+		if err != nil {
+			return s, err
+		}
+		s.cohortStudents = map[int32]bool{}
+		for _, m := range members {
+			s.cohortStudents[m.StudentID] = true
+		}
+	}
+	return s, nil
+}
+
+// hasStudent matches records that carry no term of their own.
+func (s scope) hasStudent(studentID int32) bool {
+	if s.cohortStudents != nil {
+		return s.cohortStudents[studentID]
+	}
+	return s.termStudents == nil || s.termStudents[studentID]
+}
+
+func (s scope) inCohort(studentID int32) bool {
+	return s.cohortStudents == nil || s.cohortStudents[studentID]
+}
+
+func (s scope) hasTerm(termID sql.NullInt32) bool {
+	return s.term == nil || (termID.Valid && termID.Int32 == s.term.ID)
+}
+
+func (s scope) covers(t time.Time) bool {
+	if s.term == nil {
+		return true
+	}
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return !day.Before(s.term.StartsOn) && !day.After(s.term.EndsOn)
+}
+
+// includesCompletion matches completions on their term tag and student.
+func (s scope) includesCompletion(comp database.Studentsubjectcompletion) bool {
+	return s.includesRecord(comp.TermID, comp.CompletedAt, comp.StudentID)
+}
+
+// includesRecord matches a record on its term tag when it has one, on its
+// date when it was recorded untagged, and on its student otherwise.
+func (s scope) includesRecord(termID sql.NullInt32, at sql.NullTime, studentID int32) bool {
+	switch {
+	case termID.Valid:
+		return s.hasTerm(termID) && s.inCohort(studentID)
+	case at.Valid:
+		return s.covers(at.Time) && s.inCohort(studentID)
+	default:
+		return s.hasStudent(studentID)
+	}
+}
+
+// termFor resolves the term to tag a record with: the explicit term_id when
+// given, otherwise the term whose dates contain at, if any.
+func (c *Config) termFor(ctx context.Context, termID *int32, at time.Time) (sql.NullInt32, error) {
+	if termID != nil {
+		if _, err := c.DB.GetTermByID(ctx, *termID); err != nil {
+			return sql.NullInt32{}, fmt.Errorf("unknown term %d", *termID)
+		}
+		return sql.NullInt32{Int32: *termID, Valid: true}, nil
+	}
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	term, err := c.DB.GetTermAt(ctx, database.GetTermAtParams{StartsOn: day, EndsOn: day})
+	if err == sql.ErrNoRows {
+		return sql.NullInt32{}, nil
+	} else if err != nil {
+		return sql.NullInt32{}, err
+	}
+	return sql.NullInt32{Int32: term.ID, Valid: true}, nil
+}
+
+// --- Handler for /terms (List, Create) ---
+func (c *Config) TermsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		c.listTerms(w, r)
+	case http.MethodPost:
+		c.createTerm(w, r)
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// --- Handler for /terms/{id} (Get, Update, Delete) ---
+func (c *Config) TermByIDHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		c.getTermById(w, r, int32(id))
+	case http.MethodPut:
+		c.updateTermById(w, r, int32(id))
+	case http.MethodDelete:
+		c.deleteTermById(w, r, int32(id))
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// --- Handler for /cohorts (List, Create) ---
+func (c *Config) CohortsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		c.listCohorts(w, r)
+	case http.MethodPost:
+		c.createCohort(w, r)
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// --- Handler for /cohorts/{id} (Get, Update, Delete) ---
+func (c *Config) CohortByIDHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		c.getCohortById(w, r, int32(id))
+	case http.MethodPut:
+		c.updateCohortById(w, r, int32(id))
+	case http.MethodDelete:
+		c.deleteCohortById(w, r, int32(id))
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// --- Handler for /cohorts/{id}/students (List, Add) ---
+func (c *Config) CohortStudentsHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		c.listCohortStudents(w, r, int32(id))
+	case http.MethodPost:
+		c.addCohortStudent(w, r, int32(id))
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// --- Handler for /cohorts/{id}/students/{student_id} (Remove) ---
+func (c *Config) CohortStudentByIDHandler(w http.ResponseWriter, r *http.Request) {
+	cohortID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	studentID, err := strconv.Atoi(r.PathValue("student_id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodDelete {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	result, err := c.DB.RemoveCohortStudent(r.Context(), database.RemoveCohortStudentParams{
+		CohortID:  int32(cohortID),
+		StudentID: int32(studentID),
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to remove student from cohort: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type termBody struct {
+	Name     string `json:"name"`
+	StartsOn string `json:"starts_on"`
+	EndsOn   string `json:"ends_on"`
+}
+
+// parse validates the body and returns its dates; dates are YYYY-MM-DD and
+// both ends are inclusive.
+func (b termBody) parse() (time.Time, time.Time, error) {
+	if b.Name == "" {
+		return time.Time{}, time.Time{}, fmt.Errorf("name is required")
+	}
+	startsOn, err := time.Parse(termDateLayout, b.StartsOn)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("starts_on must be a YYYY-MM-DD date")
+	}
+	endsOn, err := time.Parse(termDateLayout, b.EndsOn)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("ends_on must be a YYYY-MM-DD date")
+	}
+	if endsOn.Before(startsOn) {
+		return time.Time{}, time.Time{}, fmt.Errorf("ends_on cannot be before starts_on")
+	}
+	return startsOn, endsOn, nil
+}
+
+func (c *Config) listTerms(w http.ResponseWriter, r *http.Request) {
+	terms, err := c.DB.ListTerms(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error getting terms: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, terms)
+}
+
+func (c *Config) createTerm(w http.ResponseWriter, r *http.Request) {
+	var body termBody
+	defer r.Body.Close()
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, fmt.Sprintf("Error decoding JSON: %v", err), http.StatusBadRequest)
+		return
+	}
+	startsOn, endsOn, err := body.parse()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	result, err := c.DB.CreateTerm(r.Context(), database.CreateTermParams{
+		Name:     body.Name,
+		StartsOn: startsOn,
+		EndsOn:   endsOn,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			http.Error(w, "name already exists", http.StatusConflict)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Error creating term: %v", err), http.StatusInternalServerError)
+		return
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		http.Error(w, "Error retrieving last insert ID", http.StatusInternalServerError)
+		return
+	}
+	term, err := c.DB.GetTermByID(r.Context(), int32(id))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error creating term: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, term)
+}
+
+func (c *Config) getTermById(w http.ResponseWriter, r *http.Request, id int32) {
+	term, err := c.DB.GetTermByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, term)
+}
+
+func (c *Config) updateTermById(w http.ResponseWriter, r *http.Request, id int32) {
+	if _, err := c.DB.GetTermByID(r.Context(), id); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	var body termBody
+	defer r.Body.Close()
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, fmt.Sprintf("Error decoding JSON: %v", err), http.StatusBadRequest)
+		return
+	}
+	startsOn, endsOn, err := body.parse()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	_, err = c.DB.UpdateTerm(r.Context(), database.UpdateTermParams{
+		Name:     body.Name,
+		StartsOn: startsOn,
+		EndsOn:   endsOn,
+		ID:       id,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			http.Error(w, "name already exists", http.StatusConflict)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Error updating term: %v", err), http.StatusInternalServerError)
+		return
+	}
+	c.getTermById(w, r, id)
+}
+
+// deleteTermById handles DELETE requests to /terms/{id}. Its cohorts go with
+// it; tagged assignments and completions are kept untagged.
+func (c *Config) deleteTermById(w http.ResponseWriter, r *http.Request, id int32) {
+	if err := c.DB.DeleteTerm(r.Context(), id); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to delete term: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *Config) listCohorts(w http.ResponseWriter, r *http.Request) {
+	var cohorts []database.Cohort
+	var err error
+	if termIDStr := r.URL.Query().Get("term_id"); termIDStr != "" {
+		termID, err := strconv.Atoi(termIDStr)
+		if err != nil {
+			http.Error(w, "Invalid term_id format", http.StatusBadRequest)
+			return
+		}
+		cohorts, err = c.DB.ListCohortsByTerm(r.Context(), int32(termID))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Error getting cohorts: %v", err), http.StatusInternalServerError)
+			return
+		}
+	} else {
+		cohorts, err = c.DB.ListCohorts(r.Context())
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Error getting cohorts: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+	respondWithJSON(w, http.StatusOK, cohorts)
+}
+
+func (c *Config) createCohort(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		TermID int32  `json:"term_id"`
+		Name   string `json:"name"`
+	}
+	defer r.Body.Close()
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, fmt.Sprintf("Error decoding JSON: %v", err), http.StatusBadRequest)
+		return
+	}
+	if body.Name == "" {
+		http.Error(w, "name is required", http.StatusBadRequest)
+		return
+	}
+	if _, err := c.DB.GetTermByID(r.Context(), body.TermID); err != nil {
+		http.Error(w, fmt.Sprintf("Unknown term %d", body.TermID), http.StatusBadRequest)
+		return
+	}
+	result, err := c.DB.CreateCohort(r.Context(), database.CreateCohortParams{
+		TermID: body.TermID,
+		Name:   body.Name,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			http.Error(w, "name already exists in this term", http.StatusConflict)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Error creating cohort: %v", err), http.StatusInternalServerError)
+		return
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		http.Error(w, "Error retrieving last insert ID", http.StatusInternalServerError)
+		return
+	}
+	cohort, err := c.DB.GetCohortByID(r.Context(), int32(id))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error creating cohort: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, cohort)
+}
+
+func (c *Config) getCohortById(w http.ResponseWriter, r *http.Request, id int32) {
+	cohort, err := c.DB.GetCohortByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, cohort)
+}
+
+func (c *Config) updateCohortById(w http.ResponseWriter, r *http.Request, id int32) {
+	if _, err := c.DB.GetCohortByID(r.Context(), id); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	var body struct {
+		Name string `json:"name"`
+	}
+	defer r.Body.Close()
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, fmt.Sprintf("Error decoding JSON: %v", err), http.StatusBadRequest)
+		return
+	}
+	if body.Name == "" {
+		http.Error(w, "name is required", http.StatusBadRequest)
+		return
+	}
+	_, err := c.DB.UpdateCohort(r.Context(), database.UpdateCohortParams{Name: body.Name, ID: id})
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			http.Error(w, "name already exists in this term", http.StatusConflict)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Error updating cohort: %v", err), http.StatusInternalServerError)
+		return
+	}
+	c.getCohortById(w, r, id)
+}
+
+func (c *Config) deleteCohortById(w http.ResponseWriter, r *http.Request, id int32) {
+	if err := c.DB.DeleteCohort(r.Context(), id); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to delete cohort: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *Config) listCohortStudents(w http.ResponseWriter, r *http.Request, cohortID int32) {
+	if _, err := c.DB.GetCohortByID(r.Context(), cohortID); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	members, err := c.DB.ListCohortStudents(r.Context(), cohortID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list cohort students: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, members)
+}
+
+func (c *Config) addCohortStudent(w http.ResponseWriter, r *http.Request, cohortID int32) {
+	var body struct {
+		StudentID int32 `json:"student_id"`
+	}
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, "Invalid body", http.StatusBadRequest)
+		return
+	}
+	if _, err := c.DB.GetCohortByID(r.Context(), cohortID); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if _, err := c.DB.GetStudentByID(r.Context(), body.StudentID); err != nil {
+		http.Error(w, fmt.Sprintf("Unknown student %d", body.StudentID), http.StatusBadRequest)
+		return
+	}
+	_, err := c.DB.AddCohortStudent(r.Context(), database.AddCohortStudentParams{
+		CohortID:  cohortID,
+		StudentID: body.StudentID,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			http.Error(w, "Student is already in this cohort", http.StatusConflict)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to add student to cohort: %v", err), http.StatusInternalServerError)
+		return
+	}
+	c.listCohortStudents(w, r, cohortID)
+}
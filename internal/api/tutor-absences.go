@@ -0,0 +1,301 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/wilgnert/webtutoria/internal/database"
+)
+
+var (
+	errNoSubstitute   = errors.New("no tutor is free to substitute")
+	errAbsenceOverlap = errors.New("tutor already has an absence in this window")
+	errSubstituteAway = errors.New("substitute is away during this window")
+)
+
+// --- Handler for /tutors/{id}/absences (List, Create) ---
+func (c *Config) TutorAbsencesHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		c.listTutorAbsences(w, r, int32(id))
+	case http.MethodPost:
+		c.createTutorAbsence(w, r, int32(id))
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// --- Handler for /tutors/{id}/absences/{absence_id} (Cancel) ---
+func (c *Config) TutorAbsenceByIDHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	absenceID, err := strconv.Atoi(r.PathValue("absence_id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodDelete:
+		c.cancelTutorAbsence(w, r, int32(id), int32(absenceID))
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (c *Config) listTutorAbsences(w http.ResponseWriter, r *http.Request, tutorID int32) {
+	if _, err := c.DB.GetTutorByID(r.Context(), tutorID); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	absences, err := c.DB.ListTutorAbsencesByTutor(r.Context(), tutorID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list absences: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, absences)
+}
+
+// createTutorAbsence handles POST requests to /tutors/{id}/absences. Without
+// a substitute_id the least-loaded tutor who is present for the whole window
+// and has room for the absent tutor's students as well as their own is
+// picked. A substitute_id given explicitly is taken even over capacity.
+func (c *Config) createTutorAbsence(w http.ResponseWriter, r *http.Request, tutorID int32) {
+	var req struct {
+		StartsAt     time.Time `json:"starts_at"`
+		EndsAt       time.Time `json:"ends_at"`
+		Reason       string    `json:"reason"`
+		SubstituteID *int32    `json:"substitute_id"`
+	}
+	if err := DecodeJSON(r.Body, &req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if !req.EndsAt.After(req.StartsAt) {
+		http.Error(w, "ends_at must be after starts_at", http.StatusBadRequest)
+		return
+	}
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := c.DB.GetTutorByID(r.Context(), tutorID); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if err := c.checkActsOnTutor(r.Context(), by, tutorID); err != nil {
+		actorError(w, err)
+		return
+	}
+	if req.SubstituteID != nil {
+		if *req.SubstituteID == tutorID {
+			http.Error(w, "A tutor cannot substitute for themselves", http.StatusBadRequest)
+			return
+		}
+		if _, err := c.DB.GetTutorByID(r.Context(), *req.SubstituteID); err != nil {
+			http.Error(w, "Substitute not found", http.StatusBadRequest)
+			return
+		}
+	}
+
+	var id int64
+	err = c.inTx(r.Context(), func(q *database.Queries) error {
+		// locking every tutor row serializes concurrent absences, so the
+		// overlap check and the substitute's load still hold at the insert
+		tutors, err := q.LockTutorCapacities(r.Context())
+		if err != nil {
+			return err
+		}
+		absent, err := absentTutors(r.Context(), q, req.StartsAt, req.EndsAt)
+		if err != nil {
+			return err
+		}
+		if absent[tutorID] {
+			return errAbsenceOverlap
+		}
+		var substitute int32
+		if req.SubstituteID != nil {
+			substitute = *req.SubstituteID
+			if absent[substitute] {
+				return errSubstituteAway
+			}
+		} else {
+			substitute, err = pickSubstitute(r.Context(), q, tutors, tutorID, absent)
+			if err != nil {
+				return err
+			}
+		}
+		result, err := q.CreateTutorAbsence(r.Context(), database.CreateTutorAbsenceParams{
+			TutorID:      tutorID,
+			SubstituteID: sql.NullInt32{Int32: substitute, Valid: true},
+			StartsAt:     req.StartsAt,
+			EndsAt:       req.EndsAt,
+			Reason:       req.Reason,
+			CreatedBy:    by.Name,
+		})
+		if err != nil {
+			return err
+		}
+		id, err = result.LastInsertId()
+		return err
+	})
+	switch {
+	case errors.Is(err, errAbsenceOverlap), errors.Is(err, errSubstituteAway), errors.Is(err, errNoSubstitute):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		http.Error(w, fmt.Sprintf("Failed to create absence: %v", err), http.StatusInternalServerError)
+		return
+	}
+	absence, err := c.DB.GetTutorAbsenceByID(r.Context(), int32(id))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get absence: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, absence)
+}
+
+// cancelTutorAbsence handles DELETE requests to /tutors/{id}/absences/{absence_id}.
+// An absence that has not started is removed; one under way ends now and
+// stays on record.
+func (c *Config) cancelTutorAbsence(w http.ResponseWriter, r *http.Request, tutorID, absenceID int32) {
+	absence, err := c.DB.GetTutorAbsenceByID(r.Context(), absenceID)
+	if err != nil || absence.TutorID != tutorID {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := c.checkActsOnTutor(r.Context(), by, tutorID); err != nil {
+		actorError(w, err)
+		return
+	}
+	now := time.Now()
+	switch {
+	case !absence.EndsAt.After(now):
+		http.Error(w, "Absence has already ended", http.StatusConflict)
+		return
+	case absence.StartsAt.After(now):
+		err = c.DB.DeleteTutorAbsence(r.Context(), absenceID)
+	default:
+		err = c.DB.EndTutorAbsence(r.Context(), database.EndTutorAbsenceParams{
+			EndsAt: now,
+			ID:     absenceID,
+		})
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to cancel absence: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// absentTutors returns the tutors with an absence overlapping [from, to).
+func absentTutors(ctx context.Context, q *database.Queries, from, to time.Time) (map[int32]bool, error) {
+	absences, err := q.ListAbsencesOverlapping(ctx, database.ListAbsencesOverlappingParams{
+		StartsAt: to,
+		EndsAt:   from,
+	})
+	if err != nil {
+		return nil, err
+	}
+	absent := map[int32]bool{}
+	for _, a := range absences {
+		absent[a.TutorID] = true
+	}
+	return absent, nil
+}
+
+// pickSubstitute returns the least-loaded present tutor who can take on the
+// absent tutor's active students on top of their own.
+func pickSubstitute(ctx context.Context, q *database.Queries, tutors []database.LockTutorCapacitiesRow, tutorID int32, absent map[int32]bool) (int32, error) {
+	covering, err := q.CountStudentTutorsByTutor(ctx, tutorID)
+	if err != nil {
+		return 0, err
+	}
+	var best int32
+	bestLoad := int64(-1)
+	for _, t := range tutors {
+		if t.ID == tutorID || absent[t.ID] {
+			continue
+		}
+		load, err := q.CountStudentTutorsByTutor(ctx, t.ID)
+		if err != nil {
+			return 0, err
+		}
+		if t.MaxStudents.Valid && load+covering > int64(t.MaxStudents.Int32) {
+			continue
+		}
+		if bestLoad < 0 || load < bestLoad {
+			best, bestLoad = t.ID, load
+		}
+	}
+	if bestLoad < 0 {
+		return 0, errNoSubstitute
+	}
+	return best, nil
+}
+
+// currentAbsences returns the absences running at the given time. Coverage is
+// always worked out from them, so it lapses by itself when an absence ends.
+func (c *Config) currentAbsences(ctx context.Context, at time.Time) ([]database.Tutorabsence, error) {
+	return c.DB.ListAbsencesOverlapping(ctx, database.ListAbsencesOverlappingParams{
+		StartsAt: at.Add(time.Second),
+		EndsAt:   at,
+	})
+}
+
+// coveredAssignments returns the active assignments of the tutors the given
+// tutor is currently substituting for.
+func (c *Config) coveredAssignments(ctx context.Context, substituteID int32) ([]database.Studenttutor, error) {
+	absences, err := c.currentAbsences(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	covered := []database.Studenttutor{}
+	for _, a := range absences {
+		if !a.SubstituteID.Valid || a.SubstituteID.Int32 != substituteID {
+			continue
+		}
+		assignments, err := c.DB.ListStudentTutorsByTutor(ctx, a.TutorID)
+		if err != nil {
+			return nil, err
+		}
+		for _, st := range assignments {
+			if !st.EndedAt.Valid {
+				covered = append(covered, st)
+			}
+		}
+	}
+	return covered, nil
+}
+
+// substituteFor returns who is standing in for the tutor right now, if anyone.
+func (c *Config) substituteFor(ctx context.Context, tutorID int32) (sql.NullInt32, error) {
+	absences, err := c.currentAbsences(ctx, time.Now())
+	if err != nil {
+		return sql.NullInt32{}, err
+	}
+	for _, a := range absences {
+		if a.TutorID == tutorID && a.SubstituteID.Valid {
+			return a.SubstituteID, nil
+		}
+	}
+	return sql.NullInt32{}, nil
+}
@@ -0,0 +1,253 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/wilgnert/webtutoria/internal/database"
+)
+
+var (
+	errTutorFull       = errors.New("tutor has no free places")
+	errAlreadyAssigned = errors.New("student is already assigned to this tutor")
+	errNoTutorFree     = errors.New("no tutor has a free place")
+)
+
+type tutorCapacity struct {
+	TutorID     int32  `json:"tutor_id"`
+	MaxStudents *int32 `json:"max_students"`
+	Students    int64  `json:"students"`
+	Available   *int64 `json:"available"`
+}
+
+// --- Handler for /tutors/{id}/capacity (Get, Update) ---
+func (c *Config) TutorCapacityHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		c.getTutorCapacity(w, r, int32(id))
+	case http.MethodPut:
+		c.updateTutorCapacity(w, r, int32(id))
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// --- Handler for /students/{id}/auto-assign ---
+func (c *Config) StudentAutoAssignHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	c.autoAssignTutor(w, r, int32(id))
+}
+
+func (c *Config) getTutorCapacity(w http.ResponseWriter, r *http.Request, tutorID int32) {
+	tutor, err := c.DB.GetTutorByID(r.Context(), tutorID)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	students, err := c.DB.CountStudentTutorsByTutor(r.Context(), tutorID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to count tutor students: %v", err), http.StatusInternalServerError)
+		return
+	}
+	res := tutorCapacity{TutorID: tutor.ID, Students: students}
+	if tutor.MaxStudents.Valid {
+		limit := tutor.MaxStudents.Int32
+		available := max(int64(limit)-students, 0)
+		res.MaxStudents = &limit
+		res.Available = &available
+	}
+	respondWithJSON(w, http.StatusOK, res)
+}
+
+// updateTutorCapacity handles PUT requests to /tutors/{id}/capacity. A null
+// max_students removes the limit; lowering it below the current load keeps
+// the existing assignments but blocks new ones.
+func (c *Config) updateTutorCapacity(w http.ResponseWriter, r *http.Request, tutorID int32) {
+	var body struct {
+		MaxStudents *int32 `json:"max_students"`
+	}
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, "Invalid body", http.StatusBadRequest)
+		return
+	}
+	if body.MaxStudents != nil && *body.MaxStudents < 0 {
+		http.Error(w, "max_students must not be negative", http.StatusBadRequest)
+		return
+	}
+	if _, err := c.DB.GetTutorByID(r.Context(), tutorID); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	limit := sql.NullInt32{}
+	if body.MaxStudents != nil {
+		limit = sql.NullInt32{Int32: *body.MaxStudents, Valid: true}
+	}
+	if err := c.DB.UpdateTutorCapacity(r.Context(), database.UpdateTutorCapacityParams{
+		MaxStudents: limit,
+		ID:          tutorID,
+	}); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to update tutor capacity: %v", err), http.StatusInternalServerError)
+		return
+	}
+	c.getTutorCapacity(w, r, tutorID)
+}
+
+// autoAssignTutor handles POST requests to /students/{id}/auto-assign and
+// links the student to the least-loaded tutor with a free place.
+func (c *Config) autoAssignTutor(w http.ResponseWriter, r *http.Request, studentID int32) {
+	stud, err := c.DB.GetStudentByID(r.Context(), studentID)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if !studentIsEngaged(stud.Status) {
+		http.Error(w, fmt.Sprintf("student is %s", stud.Status), http.StatusConflict)
+		return
+	}
+	term, err := c.termFor(r.Context(), nil, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var id int64
+	err = c.inTx(r.Context(), func(q *database.Queries) error {
+		// locking every tutor row serializes concurrent auto-assigns, so two
+		// requests cannot both take a tutor's last place
+		tutors, err := q.LockTutorCapacities(r.Context())
+		if err != nil {
+			return err
+		}
+		current, err := q.ListStudentTutorsByStudent(r.Context(), studentID)
+		if err != nil {
+			return err
+		}
+		assigned := map[int32]bool{}
+		for _, st := range current {
+			if !st.EndedAt.Valid {
+				assigned[st.TutorID] = true
+			}
+		}
+		var best int32
+		bestLoad := int64(-1)
+		for _, t := range tutors {
+			if assigned[t.ID] {
+				continue
+			}
+			load, err := q.CountStudentTutorsByTutor(r.Context(), t.ID)
+			if err != nil {
+				return err
+			}
+			if t.MaxStudents.Valid && load >= int64(t.MaxStudents.Int32) {
+				continue
+			}
+			if bestLoad < 0 || load < bestLoad {
+				best, bestLoad = t.ID, load
+			}
+		}
+		if bestLoad < 0 {
+			return errNoTutorFree
+		}
+		role, err := defaultTutorRole(r.Context(), q, studentID)
+		if err != nil {
+			return err
+		}
+		id, err = createAssignment(r.Context(), q, studentID, best, term, role)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, errNoTutorFree) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to assign tutor: %v", err), http.StatusInternalServerError)
+		return
+	}
+	res, err := c.DB.GetStudentTutorByID(r.Context(), int32(id))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get student tutor after creation: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, res)
+}
+
+// assignTutor links a student to a tutor, failing with errTutorFull when the
+// tutor is at capacity, errAlreadyAssigned when the pair is already active and
+// errPrimaryTaken for a second primary. An empty role picks the default one.
+func (c *Config) assignTutor(ctx context.Context, studentID, tutorID int32, term sql.NullInt32, role string) (int64, error) {
+	var id int64
+	err := c.inTx(ctx, func(q *database.Queries) error {
+		var err error
+		id, err = assignTutorTx(ctx, q, studentID, tutorID, term, role)
+		return err
+	})
+	return id, err
+}
+
+// assignTutorTx is assignTutor for callers that already hold a transaction.
+func assignTutorTx(ctx context.Context, q *database.Queries, studentID, tutorID int32, term sql.NullInt32, role string) (int64, error) {
+	limit, err := q.LockTutorCapacity(ctx, tutorID)
+	if err != nil {
+		return 0, err
+	}
+	if limit.Valid {
+		load, err := q.CountStudentTutorsByTutor(ctx, tutorID)
+		if err != nil {
+			return 0, err
+		}
+		if load >= int64(limit.Int32) {
+			return 0, errTutorFull
+		}
+	}
+	if role == "" {
+		role, err = defaultTutorRole(ctx, q, studentID)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return createAssignment(ctx, q, studentID, tutorID, term, role)
+}
+
+func createAssignment(ctx context.Context, q *database.Queries, studentID, tutorID int32, term sql.NullInt32, role string) (int64, error) {
+	result, err := q.CreateStudentTutor(ctx, database.CreateStudentTutorParams{
+		StudentID: studentID,
+		TutorID:   tutorID,
+		TermID:    term,
+		Role:      role,
+	})
+	if err != nil {
+		return 0, assignmentError(err)
+	}
+	return result.LastInsertId()
+}
+
+// inTx runs fn on queries bound to a new transaction, committing when fn
+// succeeds and rolling back otherwise.
+func (c *Config) inTx(ctx context.Context, fn func(q *database.Queries) error) error {
+	tx, err := c.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(c.DB.WithTx(tx)); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,303 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/wilgnert/webtutoria/internal/database"
+)
+
+const (
+	minProficiency = 1
+	maxProficiency = 5
+)
+
+type tutorExpertise struct {
+	CategoryID  int32  `json:"category_id"`
+	Category    string `json:"category"`
+	Proficiency int32  `json:"proficiency"`
+}
+
+type tutorSuggestion struct {
+	TutorID         int32   `json:"tutor_id"`
+	Name            string  `json:"name"`
+	Coverage        float64 `json:"coverage"`
+	CoveredSubjects int     `json:"covered_subjects"`
+	Load            int     `json:"load"`
+	Assigned        bool    `json:"assigned"`
+}
+
+// --- Handler for /tutors/{id}/expertise (Get, Replace) ---
+func (c *Config) TutorExpertiseHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		c.getTutorExpertise(w, r, int32(id))
+	case http.MethodPut:
+		c.replaceTutorExpertise(w, r, int32(id))
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// --- Handler for /students/{id}/tutor-suggestions ---
+func (c *Config) StudentTutorSuggestionsHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	c.suggestTutors(w, r, int32(id))
+}
+
+func (c *Config) getTutorExpertise(w http.ResponseWriter, r *http.Request, tutorID int32) {
+	if _, err := c.DB.GetTutorByID(r.Context(), tutorID); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	links, err := c.DB.ListTutorCategoriesByTutor(r.Context(), tutorID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list tutor expertise: %v", err), http.StatusInternalServerError)
+		return
+	}
+	expertise := []tutorExpertise{}
+	for _, link := range links {
+		cat, err := c.DB.GetCategoryByID(r.Context(), link.CategoryID)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to get category: %v", err), http.StatusInternalServerError)
+			return
+		}
+		expertise = append(expertise, tutorExpertise{
+			CategoryID:  cat.ID,
+			Category:    cat.Name,
+			Proficiency: link.Proficiency,
+		})
+	}
+	respondWithJSON(w, http.StatusOK, expertise)
+}
+
+// replaceTutorExpertise handles PUT requests to /tutors/{id}/expertise with
+// the full list of categories, by name, and the tutor's proficiency in each.
+func (c *Config) replaceTutorExpertise(w http.ResponseWriter, r *http.Request, tutorID int32) {
+	if _, err := c.DB.GetTutorByID(r.Context(), tutorID); err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	var body []struct {
+		Category    string `json:"category"`
+		Proficiency int32  `json:"proficiency"`
+	}
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, "Invalid body", http.StatusBadRequest)
+		return
+	}
+	params := []database.CreateTutorCategoryParams{}
+	seen := map[int32]bool{}
+	for _, item := range body {
+		if item.Proficiency < minProficiency || item.Proficiency > maxProficiency {
+			http.Error(w, fmt.Sprintf("proficiency must be between %d and %d", minProficiency, maxProficiency), http.StatusBadRequest)
+			return
+		}
+		cat, err := c.DB.GetCategoryByName(r.Context(), item.Category)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unknown category %q", item.Category), http.StatusBadRequest)
+			return
+		}
+		if seen[cat.ID] {
+			http.Error(w, fmt.Sprintf("duplicate category %q", item.Category), http.StatusBadRequest)
+			return
+		}
+		seen[cat.ID] = true
+		params = append(params, database.CreateTutorCategoryParams{
+			TutorID:     tutorID,
+			CategoryID:  cat.ID,
+			Proficiency: item.Proficiency,
+		})
+	}
+	err := c.inTx(r.Context(), func(q *database.Queries) error {
+		if err := q.DeleteTutorCategoriesByTutor(r.Context(), tutorID); err != nil {
+			return err
+		}
+		for _, p := range params {
+			if _, err := q.CreateTutorCategory(r.Context(), p); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to save tutor expertise: %v", err), http.StatusInternalServerError)
+		return
+	}
+	c.getTutorExpertise(w, r, tutorID)
+}
+
+// outstandingSubjects returns the subjects the student still has to pass:
+// those of their class and of the learning paths they are enrolled in, or
+// every subject when they have neither.
+func (c *Config) outstandingSubjects(ctx context.Context, stud database.Student) ([]database.Subject, error) {
+	all, err := c.DB.ListSubjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	completions, err := c.DB.ListStudentSubjectCompletionsByStudent(ctx, stud.ID)
+	if err != nil {
+		return nil, err
+	}
+	passed := map[int32]bool{}
+	for _, comp := range completions {
+		if comp.Passed {
+			passed[comp.SubjectID] = true
+		}
+	}
+	enrollments, err := c.DB.ListStudentLearningPaths(ctx, stud.ID)
+	if err != nil {
+		return nil, err
+	}
+	inPath := map[int32]bool{}
+	for _, enrollment := range enrollments {
+		steps, err := c.DB.ListLearningPathSubjects(ctx, enrollment.PathID)
+		if err != nil {
+			return nil, err
+		}
+		for _, step := range steps {
+			inPath[step.SubjectID] = true
+		}
+	}
+	scoped := stud.Class.Valid || len(inPath) > 0
+	outstanding := []database.Subject{}
+	for _, sub := range all {
+		if passed[sub.ID] {
+			continue
+		}
+		if scoped && !inPath[sub.ID] && !(stud.Class.Valid && sub.Class == stud.Class.String) {
+			continue
+		}
+		outstanding = append(outstanding, sub)
+	}
+	return outstanding, nil
+}
+
+// suggestTutors handles GET requests to /students/{id}/tutor-suggestions.
+// Tutors are ranked by how well their expertise covers the student's
+// outstanding subjects, then by how few engaged students they already have.
+// Expertise in a category also covers its sub-categories, and coverage weighs
+// each subject by the tutor's proficiency.
+func (c *Config) suggestTutors(w http.ResponseWriter, r *http.Request, studentID int32) {
+	stud, err := c.DB.GetStudentByID(r.Context(), studentID)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	outstanding, err := c.outstandingSubjects(r.Context(), stud)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list outstanding subjects: %v", err), http.StatusInternalServerError)
+		return
+	}
+	tree, err := c.loadCategoryTree(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to load categories: %v", err), http.StatusInternalServerError)
+		return
+	}
+	links, err := c.DB.ListSubjectCategories(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to load subject categories: %v", err), http.StatusInternalServerError)
+		return
+	}
+	// every category that covers a subject, directly or as an ancestor
+	covering := map[int32]map[int32]bool{}
+	for _, link := range links {
+		if covering[link.SubjectID] == nil {
+			covering[link.SubjectID] = map[int32]bool{}
+		}
+		for id := link.CategoryID; ; {
+			if covering[link.SubjectID][id] {
+				break
+			}
+			covering[link.SubjectID][id] = true
+			parent := tree.byID[id].ParentID
+			if !parent.Valid {
+				break
+			}
+			id = parent.Int32
+		}
+	}
+	expertise, err := c.DB.ListTutorCategories(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list tutor expertise: %v", err), http.StatusInternalServerError)
+		return
+	}
+	proficiency := map[int32]map[int32]int32{}
+	for _, e := range expertise {
+		if proficiency[e.TutorID] == nil {
+			proficiency[e.TutorID] = map[int32]int32{}
+		}
+		proficiency[e.TutorID][e.CategoryID] = e.Proficiency
+	}
+	engaged, err := c.engagedStudents(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list students: %v", err), http.StatusInternalServerError)
+		return
+	}
+	tutors, err := c.DB.GetAllTutors(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list tutors: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	suggestions := []tutorSuggestion{}
+	for _, tutor := range tutors {
+		s := tutorSuggestion{TutorID: tutor.ID, Name: tutor.Name}
+		assignments, err := c.DB.ListStudentTutorsByTutor(r.Context(), tutor.ID)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to list tutor students: %v", err), http.StatusInternalServerError)
+			return
+		}
+		for _, a := range assignments {
+			if a.EndedAt.Valid {
+				continue
+			}
+			if a.StudentID == studentID {
+				s.Assigned = true
+			}
+			if engaged[a.StudentID] {
+				s.Load++
+			}
+		}
+		var weighted float64
+		for _, sub := range outstanding {
+			var best int32
+			for catID := range covering[sub.ID] {
+				if p := proficiency[tutor.ID][catID]; p > best {
+					best = p
+				}
+			}
+			if best > 0 {
+				s.CoveredSubjects++
+				weighted += float64(best) / maxProficiency
+			}
+		}
+		if len(outstanding) > 0 {
+			s.Coverage = weighted / float64(len(outstanding)) * 100
+		}
+		suggestions = append(suggestions, s)
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Coverage != suggestions[j].Coverage {
+			return suggestions[i].Coverage > suggestions[j].Coverage
+		}
+		return suggestions[i].Load < suggestions[j].Load
+	})
+	respondWithJSON(w, http.StatusOK, suggestions)
+}
@@ -0,0 +1,463 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/wilgnert/webtutoria/internal/database"
+)
+
+const (
+	requestPending  = "pending"
+	requestAccepted = "accepted"
+	requestDeclined = "declined"
+)
+
+var (
+	errRequestDecided = errors.New("request is no longer pending")
+	errNotExpert      = errors.New("tutor has no expertise in this request's category")
+)
+
+type tutorRequestView struct {
+	ID             int32      `json:"id"`
+	StudentID      int32      `json:"student_id"`
+	TutorID        *int32     `json:"tutor_id"`
+	CategoryID     *int32     `json:"category_id"`
+	Message        *string    `json:"message"`
+	Status         string     `json:"status"`
+	DecisionReason *string    `json:"decision_reason"`
+	DecidedBy      *string    `json:"decided_by"`
+	DecidedAt      *time.Time `json:"decided_at"`
+	AssignmentID   *int32     `json:"assignment_id"`
+	CreatedAt      time.Time  `json:"created_at"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+}
+
+func newTutorRequestView(req database.Tutorrequest) tutorRequestView {
+	return tutorRequestView{
+		ID:             req.ID,
+		StudentID:      req.StudentID,
+		TutorID:        nullInt32Ptr(req.TutorID),
+		CategoryID:     nullInt32Ptr(req.CategoryID),
+		Message:        nullStringPtr(req.Message),
+		Status:         req.Status,
+		DecisionReason: nullStringPtr(req.DecisionReason),
+		DecidedBy:      nullStringPtr(req.DecidedBy),
+		DecidedAt:      nullTimePtr(req.DecidedAt),
+		AssignmentID:   nullInt32Ptr(req.AssignmentID),
+		CreatedAt:      req.CreatedAt,
+		ExpiresAt:      req.ExpiresAt,
+	}
+}
+
+// --- Handler for /tutor-requests (List, Create) ---
+func (c *Config) TutorRequestsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		c.listTutorRequests(w, r)
+	case http.MethodPost:
+		c.createTutorRequest(w, r)
+	default:
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// --- Handler for /tutor-requests/{id} (Get) ---
+func (c *Config) TutorRequestByIDHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := c.expireTutorRequests(r); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to expire tutor requests: %v", err), http.StatusInternalServerError)
+		return
+	}
+	req, err := c.DB.GetTutorRequestByID(r.Context(), int32(id))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, newTutorRequestView(req))
+}
+
+// --- Handler for /tutor-requests/{id}/accept ---
+func (c *Config) TutorRequestAcceptHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	c.acceptTutorRequest(w, r, int32(id))
+}
+
+// --- Handler for /tutor-requests/{id}/decline ---
+func (c *Config) TutorRequestDeclineHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	c.declineTutorRequest(w, r, int32(id))
+}
+
+// expireTutorRequests marks pending requests past their expiry as expired.
+// It runs before requests are read, so no background job is needed.
+func (c *Config) expireTutorRequests(r *http.Request) error {
+	return c.DB.ExpireTutorRequests(r.Context(), time.Now())
+}
+
+// tutorExpertiseCategories returns the categories the tutor has expertise in, with
+// their subcategories.
+func (c *Config) tutorExpertiseCategories(ctx context.Context, tutorID int32) (map[int32]bool, error) {
+	links, err := c.DB.ListTutorCategoriesByTutor(ctx, tutorID)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := c.loadCategoryTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	expertise := map[int32]bool{}
+	for _, link := range links {
+		for id := range tree.descendants(link.CategoryID) {
+			expertise[id] = true
+		}
+	}
+	return expertise, nil
+}
+
+// decided fails with errRequestDecided when a DecideTutorRequest changed no
+// row, because the request was decided or expired concurrently.
+func decided(result sql.Result) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errRequestDecided
+	}
+	return nil
+}
+
+// listTutorRequests handles GET requests to /tutor-requests, filtered by
+// ?status= and ?student_id=. With ?tutor_id= it lists the tutor's inbox: the
+// requests naming them and the open ones in categories they have expertise in.
+func (c *Config) listTutorRequests(w http.ResponseWriter, r *http.Request) {
+	if err := c.expireTutorRequests(r); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to expire tutor requests: %v", err), http.StatusInternalServerError)
+		return
+	}
+	query := r.URL.Query()
+	status := query.Get("status")
+	var studentID, tutorID int
+	var err error
+	if v := query.Get("student_id"); v != "" {
+		if studentID, err = strconv.Atoi(v); err != nil {
+			http.Error(w, "Invalid student_id format", http.StatusBadRequest)
+			return
+		}
+	}
+	var expertise map[int32]bool
+	if v := query.Get("tutor_id"); v != "" {
+		if tutorID, err = strconv.Atoi(v); err != nil {
+			http.Error(w, "Invalid tutor_id format", http.StatusBadRequest)
+			return
+		}
+		expertise, err = c.tutorExpertiseCategories(r.Context(), int32(tutorID))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to list tutor expertise: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+	requests, err := c.DB.ListTutorRequests(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list tutor requests: %v", err), http.StatusInternalServerError)
+		return
+	}
+	filtered := []tutorRequestView{}
+	for _, req := range requests {
+		if status != "" && req.Status != status {
+			continue
+		}
+		if studentID != 0 && req.StudentID != int32(studentID) {
+			continue
+		}
+		if tutorID != 0 {
+			named := req.TutorID.Valid && req.TutorID.Int32 == int32(tutorID)
+			open := !req.TutorID.Valid && (!req.CategoryID.Valid || expertise[req.CategoryID.Int32])
+			if !named && !open {
+				continue
+			}
+		}
+		filtered = append(filtered, newTutorRequestView(req))
+	}
+	respondWithJSON(w, http.StatusOK, filtered)
+}
+
+// createTutorRequest handles POST requests to /tutor-requests. A student may
+// name a tutor or a category, or neither, and has one pending request at a
+// time.
+func (c *Config) createTutorRequest(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		StudentID int32  `json:"student_id"`
+		TutorID   *int32 `json:"tutor_id"`
+		Category  string `json:"category"`
+		Message   string `json:"message"`
+	}
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := c.expireTutorRequests(r); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to expire tutor requests: %v", err), http.StatusInternalServerError)
+		return
+	}
+	stud, err := c.DB.GetStudentByID(r.Context(), body.StudentID)
+	if err != nil {
+		http.Error(w, "Student not found", http.StatusBadRequest)
+		return
+	}
+	if !studentIsEngaged(stud.Status) {
+		http.Error(w, fmt.Sprintf("student is %s", stud.Status), http.StatusConflict)
+		return
+	}
+	tutor := sql.NullInt32{}
+	if body.TutorID != nil {
+		if _, err := c.DB.GetTutorByID(r.Context(), *body.TutorID); err != nil {
+			http.Error(w, "Tutor not found", http.StatusBadRequest)
+			return
+		}
+		tutor = sql.NullInt32{Int32: *body.TutorID, Valid: true}
+	}
+	category := sql.NullInt32{}
+	if body.Category != "" {
+		cat, err := c.DB.GetCategoryByName(r.Context(), body.Category)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Unknown category %q", body.Category), http.StatusBadRequest)
+			return
+		}
+		category = sql.NullInt32{Int32: cat.ID, Valid: true}
+	}
+	pending, err := c.DB.ListPendingTutorRequestsByStudent(r.Context(), stud.ID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to list tutor requests: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if len(pending) > 0 {
+		http.Error(w, "Student already has a pending tutor request", http.StatusConflict)
+		return
+	}
+	result, err := c.DB.CreateTutorRequest(r.Context(), database.CreateTutorRequestParams{
+		StudentID:  stud.ID,
+		TutorID:    tutor,
+		CategoryID: category,
+		Message:    optionalString(body.Message),
+		ExpiresAt:  time.Now().Add(c.TutorRequestTTL),
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to create tutor request: %v", err), http.StatusInternalServerError)
+		return
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to retrieve new request ID: %v", err), http.StatusInternalServerError)
+		return
+	}
+	req, err := c.DB.GetTutorRequestByID(r.Context(), int32(id))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get tutor request: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusCreated, newTutorRequestView(req))
+}
+
+// acceptTutorRequest handles POST requests to /tutor-requests/{id}/accept.
+// The accepting tutor is the X-Tutor-ID caller, the tutor named in the
+// request or, for an admin, the body's tutor_id. Acceptance and the new
+// assignment are committed together, so capacity is enforced and a request
+// cannot be accepted twice. A tutor accepting an open request for a category
+// must have expertise in it.
+func (c *Config) acceptTutorRequest(w http.ResponseWriter, r *http.Request, id int32) {
+	var body struct {
+		TutorID *int32 `json:"tutor_id"`
+		Role    string `json:"role"`
+	}
+	if err := DecodeJSON(r.Body, &body); err != nil && err != io.EOF {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if body.Role != "" && !validTutorRole(body.Role) {
+		http.Error(w, "role must be primary, secondary or observer", http.StatusBadRequest)
+		return
+	}
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := c.expireTutorRequests(r); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to expire tutor requests: %v", err), http.StatusInternalServerError)
+		return
+	}
+	req, err := c.DB.GetTutorRequestByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	tutor := req.TutorID
+	switch {
+	case by.TutorID.Valid:
+		if tutor.Valid && tutor.Int32 != by.TutorID.Int32 {
+			http.Error(w, "This request names another tutor", http.StatusForbidden)
+			return
+		}
+		if !tutor.Valid && req.CategoryID.Valid {
+			expertise, err := c.tutorExpertiseCategories(r.Context(), by.TutorID.Int32)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("Failed to list tutor expertise: %v", err), http.StatusInternalServerError)
+				return
+			}
+			if !expertise[req.CategoryID.Int32] {
+				http.Error(w, errNotExpert.Error(), http.StatusForbidden)
+				return
+			}
+		}
+		tutor = by.TutorID
+	case !tutor.Valid && body.TutorID != nil:
+		tutor = sql.NullInt32{Int32: *body.TutorID, Valid: true}
+	}
+	if !tutor.Valid {
+		http.Error(w, "tutor_id is required", http.StatusBadRequest)
+		return
+	}
+	term, err := c.termFor(r.Context(), nil, time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = c.inTx(r.Context(), func(q *database.Queries) error {
+		locked, err := q.LockTutorRequest(r.Context(), id)
+		if err != nil {
+			return err
+		}
+		if locked.Status != requestPending {
+			return errRequestDecided
+		}
+		assignment, err := assignTutorTx(r.Context(), q, locked.StudentID, tutor.Int32, term, body.Role)
+		if err != nil {
+			return err
+		}
+		result, err := q.DecideTutorRequest(r.Context(), database.DecideTutorRequestParams{
+			Status:       requestAccepted,
+			DecidedBy:    sql.NullString{String: by.Name, Valid: true},
+			DecidedAt:    sql.NullTime{Time: time.Now(), Valid: true},
+			AssignmentID: sql.NullInt32{Int32: int32(assignment), Valid: true},
+			ID:           id,
+		})
+		if err != nil {
+			return err
+		}
+		return decided(result)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, errRequestDecided), errors.Is(err, errTutorFull),
+			errors.Is(err, errAlreadyAssigned), errors.Is(err, errPrimaryTaken):
+			http.Error(w, err.Error(), http.StatusConflict)
+		case err == sql.ErrNoRows:
+			http.Error(w, "Tutor not found", http.StatusBadRequest)
+		default:
+			http.Error(w, fmt.Sprintf("Failed to accept tutor request: %v", err), http.StatusInternalServerError)
+		}
+		return
+	}
+	req, err = c.DB.GetTutorRequestByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get tutor request: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, newTutorRequestView(req))
+}
+
+// declineTutorRequest handles POST requests to /tutor-requests/{id}/decline.
+// The decision only applies while the request is still pending, so a decline
+// racing an accept or the expiry gets a 409.
+func (c *Config) declineTutorRequest(w http.ResponseWriter, r *http.Request, id int32) {
+	var body struct {
+		Reason string `json:"reason"`
+	}
+	if err := DecodeJSON(r.Body, &body); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if body.Reason == "" {
+		http.Error(w, "A reason is required to decline a request", http.StatusBadRequest)
+		return
+	}
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := c.expireTutorRequests(r); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to expire tutor requests: %v", err), http.StatusInternalServerError)
+		return
+	}
+	req, err := c.DB.GetTutorRequestByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if req.Status != requestPending {
+		http.Error(w, errRequestDecided.Error(), http.StatusConflict)
+		return
+	}
+	// open requests stay visible to every tutor, so only an admin declines them
+	if by.TutorID.Valid && (!req.TutorID.Valid || req.TutorID.Int32 != by.TutorID.Int32) {
+		http.Error(w, "Only the named tutor or an admin can decline this request", http.StatusForbidden)
+		return
+	}
+	result, err := c.DB.DecideTutorRequest(r.Context(), database.DecideTutorRequestParams{
+		Status:         requestDeclined,
+		DecisionReason: sql.NullString{String: body.Reason, Valid: true},
+		DecidedBy:      sql.NullString{String: by.Name, Valid: true},
+		DecidedAt:      sql.NullTime{Time: time.Now(), Valid: true},
+		ID:             id,
+	})
+	if err == nil {
+		err = decided(result)
+	}
+	if err != nil {
+		if err == errRequestDecided {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to decline tutor request: %v", err), http.StatusInternalServerError)
+		return
+	}
+	req, err = c.DB.GetTutorRequestByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get tutor request: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, newTutorRequestView(req))
+}
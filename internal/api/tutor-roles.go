@@ -0,0 +1,167 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/wilgnert/webtutoria/internal/database"
+)
+
+const (
+	tutorPrimary   = "primary"
+	tutorSecondary = "secondary"
+	tutorObserver  = "observer"
+)
+
+var errPrimaryTaken = errors.New("student already has a primary tutor")
+
+func validTutorRole(role string) bool {
+	return role == tutorPrimary || role == tutorSecondary || role == tutorObserver
+}
+
+// defaultTutorRole makes a new assignment primary when the student has no
+// active primary tutor yet, and secondary otherwise.
+func defaultTutorRole(ctx context.Context, q *database.Queries, studentID int32) (string, error) {
+	_, err := q.GetPrimaryTutorByStudent(ctx, studentID)
+	if err == sql.ErrNoRows {
+		return tutorPrimary, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return tutorSecondary, nil
+}
+
+// assignmentError maps the unique keys on StudentTutor to their errors.
+func assignmentError(err error) error {
+	if !strings.Contains(err.Error(), "Duplicate entry") {
+		return err
+	}
+	if strings.Contains(err.Error(), "uq_active_primary") {
+		return errPrimaryTaken
+	}
+	return errAlreadyAssigned
+}
+
+// updateStudentTutorRole handles PUT requests to /students-tutors/{id}. Only
+// an admin or a tutor responsible for the student changes a role.
+func (c *Config) updateStudentTutorRole(w http.ResponseWriter, r *http.Request, id int64) {
+	var req struct {
+		Role string `json:"role"`
+	}
+	if err := DecodeJSON(r.Body, &req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if !validTutorRole(req.Role) {
+		http.Error(w, "role must be primary, secondary or observer", http.StatusBadRequest)
+		return
+	}
+	studentTutor, err := c.DB.GetStudentTutorByID(r.Context(), int32(id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "Student Tutor not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to get student tutor: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if studentTutor.EndedAt.Valid {
+		http.Error(w, "Assignment has ended", http.StatusConflict)
+		return
+	}
+	by, err := c.actorFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := c.checkActsOn(r.Context(), by, studentTutor.StudentID); err != nil {
+		actorError(w, err)
+		return
+	}
+	if studentTutor.Role == req.Role {
+		c.getStudentTutorByID(w, r, id)
+		return
+	}
+	result, err := c.DB.UpdateStudentTutorRole(r.Context(), database.UpdateStudentTutorRoleParams{
+		Role: req.Role,
+		ID:   int32(id),
+	})
+	if err != nil {
+		if errors.Is(assignmentError(err), errPrimaryTaken) {
+			http.Error(w, errPrimaryTaken.Error(), http.StatusConflict)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to update student tutor: %v", err), http.StatusInternalServerError)
+		return
+	}
+	// the assignment may have ended since it was read
+	if n, err := result.RowsAffected(); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to update student tutor: %v", err), http.StatusInternalServerError)
+		return
+	} else if n == 0 {
+		http.Error(w, "Assignment has ended", http.StatusConflict)
+		return
+	}
+	c.getStudentTutorByID(w, r, id)
+}
+
+// --- Handler for /students/{id}/discord-routing ---
+// Notifications about a student go to the Discord role and channel of their
+// primary tutor only, or of the substitute while that tutor is away.
+func (c *Config) StudentDiscordRoutingHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	routing, err := c.discordRouting(r.Context(), int32(id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			http.Error(w, "Student has no primary tutor", http.StatusNotFound)
+			return
+		}
+		http.Error(w, fmt.Sprintf("Failed to get Discord routing: %v", err), http.StatusInternalServerError)
+		return
+	}
+	respondWithJSON(w, http.StatusOK, routing)
+}
+
+// discordRouting returns the tutor, role and channel that notifications
+// about the student go to, or sql.ErrNoRows when they have no primary tutor.
+func (c *Config) discordRouting(ctx context.Context, studentID int32) (map[string]any, error) {
+	tutor, err := c.DB.GetPrimaryTutorByStudent(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+	res := map[string]any{
+		"student_id": studentID,
+		"tutor_id":   tutor.ID,
+		"role_id":    tutor.RoleID,
+		"channel_id": tutor.ChannelID,
+	}
+	substitute, err := c.substituteFor(ctx, tutor.ID)
+	if err != nil {
+		return nil, err
+	}
+	if substitute.Valid {
+		sub, err := c.DB.GetTutorByID(ctx, substitute.Int32)
+		if err != nil {
+			return nil, err
+		}
+		res["tutor_id"] = sub.ID
+		res["role_id"] = sub.RoleID
+		res["channel_id"] = sub.ChannelID
+		res["covering_for"] = tutor.ID
+	}
+	return res, nil
+}
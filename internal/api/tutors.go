@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/wilgnert/webtutoria/internal/database"
 )
@@ -30,14 +31,14 @@ func (c *Config) listTutors(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "Not found", http.StatusNotFound)
 			return
 		}
-		respondWithJSON(w, 200, studs)
+		respondWithJSON(w, 200, newTutorViews(studs))
 	} else {
 		studs, err := c.DB.GetAllTutors(r.Context())
 		if err != nil {
 			http.Error(w, fmt.Sprintf("Error getting tutors: %v", err), http.StatusInternalServerError)
 			return
 		}
-		respondWithJSON(w, 200, studs)
+		respondWithJSON(w, 200, newTutorViews(studs))
 	}
 }
 func (c *Config) createTutor(w http.ResponseWriter, r *http.Request) {
@@ -45,6 +46,7 @@ func (c *Config) createTutor(w http.ResponseWriter, r *http.Request) {
 		Name string `json:"name"`
 		RoleID string `json:"role_id"`
 		ChannelID string `json:"channel_id"`
+		profile
 	}
 	var newReqBody reqBody
 	defer r.Body.Close()
@@ -52,12 +54,28 @@ func (c *Config) createTutor(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, fmt.Sprintf("Error decoding JSON: %v", err), http.StatusBadRequest)
 		return
 	}
+	if err := newReqBody.profile.validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	result, err := c.DB.CreateTutor(r.Context(), database.CreateTutorParams{
 		Name: newReqBody.Name,
 		RoleID: newReqBody.RoleID,
 		ChannelID: newReqBody.ChannelID,
+		Email: optionalString(newReqBody.Email),
+		PreferredName: optionalString(newReqBody.PreferredName),
+		Pronouns: optionalString(newReqBody.Pronouns),
+		Timezone: optionalString(newReqBody.Timezone),
+		Locale: optionalString(newReqBody.Locale),
+		EnrolledOn: newReqBody.enrolledOn(),
+		Bio: optionalString(newReqBody.Bio),
+		AvatarUrl: optionalString(newReqBody.AvatarURL),
 	})
 	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			http.Error(w, "email already in use", http.StatusConflict)
+			return
+		}
 		http.Error(w, fmt.Sprintf("Error creating tutor: %v", err), http.StatusInternalServerError)
 		return
 	}
@@ -71,7 +89,7 @@ func (c *Config) createTutor(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, fmt.Sprintf("Error creating tutor: %v", err), http.StatusInternalServerError)
 		return
 	}
-	respondWithJSON(w, 200, stud)
+	respondWithJSON(w, 200, newTutorView(stud))
 }
 
 func (c *Config) TutorsByIdHandler(w http.ResponseWriter, r *http.Request) {
@@ -96,7 +114,7 @@ func (c *Config) getTutorById(w http.ResponseWriter, r *http.Request, id int32)
 		http.Error(w, "Not found", http.StatusNotFound)
 		return
 	}
-	respondWithJSON(w, 200, stud)
+	respondWithJSON(w, 200, newTutorView(stud))
 }
 func (c *Config) updateTutorById(w http.ResponseWriter, r *http.Request, id int32) {
 	type reqBody struct {
@@ -125,5 +143,5 @@ func (c *Config) updateTutorById(w http.ResponseWriter, r *http.Request, id int3
 		http.Error(w, fmt.Sprintf("Error updating tutor: %v", err), http.StatusInternalServerError)
 		return
 	}
-	respondWithJSON(w, 200, stud)
+	respondWithJSON(w, 200, newTutorView(stud))
 }
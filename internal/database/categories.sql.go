@@ -18,6 +18,19 @@ func (q *Queries) CreateCategory(ctx context.Context, name string) (sql.Result,
 	return q.db.ExecContext(ctx, createCategory, name)
 }
 
+const createCategoryWithParent = `-- name: CreateCategoryWithParent :execresult
+insert into Categories (name, parent_id) values (?, ?)
+`
+
+type CreateCategoryWithParentParams struct {
+	Name     string        `json:"name"`
+	ParentID sql.NullInt32 `json:"parent_id"`
+}
+
+func (q *Queries) CreateCategoryWithParent(ctx context.Context, arg CreateCategoryWithParentParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, createCategoryWithParent, arg.Name, arg.ParentID)
+}
+
 const deleteCategory = `-- name: DeleteCategory :execresult
 delete from Categories
 where id = ?
@@ -28,7 +41,7 @@ func (q *Queries) DeleteCategory(ctx context.Context, id int32) (sql.Result, err
 }
 
 const getAllCategories = `-- name: GetAllCategories :many
-select id, name from Categories
+select id, name, parent_id from Categories
 order by name
 `
 
@@ -41,7 +54,7 @@ func (q *Queries) GetAllCategories(ctx context.Context) ([]Category, error) {
 	items := []Category{}
 	for rows.Next() {
 		var i Category
-		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+		if err := rows.Scan(&i.ID, &i.Name, &i.ParentID); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -56,26 +69,26 @@ func (q *Queries) GetAllCategories(ctx context.Context) ([]Category, error) {
 }
 
 const getCategoryByID = `-- name: GetCategoryByID :one
-select id, name from Categories
+select id, name, parent_id from Categories
 where id = ?
 `
 
 func (q *Queries) GetCategoryByID(ctx context.Context, id int32) (Category, error) {
 	row := q.db.QueryRowContext(ctx, getCategoryByID, id)
 	var i Category
-	err := row.Scan(&i.ID, &i.Name)
+	err := row.Scan(&i.ID, &i.Name, &i.ParentID)
 	return i, err
 }
 
 const getCategoryByName = `-- name: GetCategoryByName :one
-select id, name from Categories
+select id, name, parent_id from Categories
 where name = ?
 `
 
 func (q *Queries) GetCategoryByName(ctx context.Context, name string) (Category, error) {
 	row := q.db.QueryRowContext(ctx, getCategoryByName, name)
 	var i Category
-	err := row.Scan(&i.ID, &i.Name)
+	err := row.Scan(&i.ID, &i.Name, &i.ParentID)
 	return i, err
 }
 
@@ -93,3 +106,19 @@ type UpdateCategoryParams struct {
 func (q *Queries) UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (sql.Result, error) {
 	return q.db.ExecContext(ctx, updateCategory, arg.Name, arg.ID)
 }
+
+const updateCategoryParent = `-- name: UpdateCategoryParent :exec
+update Categories
+set parent_id = ?
+where id = ?
+`
+
+type UpdateCategoryParentParams struct {
+	ParentID sql.NullInt32 `json:"parent_id"`
+	ID       int32         `json:"id"`
+}
+
+func (q *Queries) UpdateCategoryParent(ctx context.Context, arg UpdateCategoryParentParams) error {
+	_, err := q.db.ExecContext(ctx, updateCategoryParent, arg.ParentID, arg.ID)
+	return err
+}
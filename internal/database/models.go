@@ -9,14 +9,186 @@ import (
 	"time"
 )
 
+type Badge struct {
+	ID          int32          `json:"id"`
+	Code        string         `json:"code"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+	Rule        string         `json:"rule"`
+	CategoryID  sql.NullInt32  `json:"category_id"`
+	Threshold   int32          `json:"threshold"`
+	Announce    bool           `json:"announce"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
 type Category struct {
-	ID   int32  `json:"id"`
-	Name string `json:"name"`
+	ID       int32         `json:"id"`
+	Name     string        `json:"name"`
+	ParentID sql.NullInt32 `json:"parent_id"`
+}
+
+type Checklistitem struct {
+	ID        int32  `json:"id"`
+	SubjectID int32  `json:"subject_id"`
+	Label     string `json:"label"`
+	Required  bool   `json:"required"`
+	Position  int32  `json:"position"`
+}
+
+type Class struct {
+	ID          int32          `json:"id"`
+	Code        string         `json:"code"`
+	Name        string         `json:"name"`
+	Position    int32          `json:"position"`
+	Description sql.NullString `json:"description"`
+}
+
+type Classprogressionrule struct {
+	ID         int32         `json:"id"`
+	ClassID    int32         `json:"class_id"`
+	Kind       string        `json:"kind"`
+	CategoryID sql.NullInt32 `json:"category_id"`
+	MinCount   int32         `json:"min_count"`
+}
+
+type Cohort struct {
+	ID     int32  `json:"id"`
+	TermID int32  `json:"term_id"`
+	Name   string `json:"name"`
+}
+
+type Cohortstudent struct {
+	ID        int32 `json:"id"`
+	CohortID  int32 `json:"cohort_id"`
+	StudentID int32 `json:"student_id"`
+}
+
+type Completionamendment struct {
+	ID               int32          `json:"id"`
+	CompletionID     int32          `json:"completion_id"`
+	Field            string         `json:"field"`
+	OldValue         sql.NullString `json:"old_value"`
+	NewValue         sql.NullString `json:"new_value"`
+	Reason           string         `json:"reason"`
+	AmendedByTutorID sql.NullInt32  `json:"amended_by_tutor_id"`
+	AmendedBy        string         `json:"amended_by"`
+	CreatedAt        time.Time      `json:"created_at"`
+}
+
+type Completionscore struct {
+	ID           int32   `json:"id"`
+	CompletionID int32   `json:"completion_id"`
+	RubricItemID int32   `json:"rubric_item_id"`
+	Points       float64 `json:"points"`
+}
+
+type Event struct {
+	ID        int32         `json:"id"`
+	Kind      string        `json:"kind"`
+	StudentID sql.NullInt32 `json:"student_id"`
+	Payload   string        `json:"payload"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type Helprequest struct {
+	ID            int32          `json:"id"`
+	StudentID     int32          `json:"student_id"`
+	SubjectID     int32          `json:"subject_id"`
+	Message       sql.NullString `json:"message"`
+	Priority      int32          `json:"priority"`
+	Status        string         `json:"status"`
+	TutorID       sql.NullInt32  `json:"tutor_id"`
+	CreatedAt     time.Time      `json:"created_at"`
+	QueuedAt      time.Time      `json:"queued_at"`
+	ClaimedAt     sql.NullTime   `json:"claimed_at"`
+	ResolvedAt    sql.NullTime   `json:"resolved_at"`
+	WaitSeconds   int32          `json:"wait_seconds"`
+	HandleSeconds int32          `json:"handle_seconds"`
+	Handbacks     int32          `json:"handbacks"`
+	Resolution    sql.NullString `json:"resolution"`
+}
+
+type Learningpath struct {
+	ID          int32          `json:"id"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+}
+
+type Learningpathsubject struct {
+	ID        int32 `json:"id"`
+	PathID    int32 `json:"path_id"`
+	SubjectID int32 `json:"subject_id"`
+	Position  int32 `json:"position"`
+}
+
+type Note struct {
+	ID            int32         `json:"id"`
+	StudentID     int32         `json:"student_id"`
+	CompletionID  sql.NullInt32 `json:"completion_id"`
+	AuthorTutorID sql.NullInt32 `json:"author_tutor_id"`
+	Author        string        `json:"author"`
+	Visibility    string        `json:"visibility"`
+	Body          string        `json:"body"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+}
+
+type Noterevision struct {
+	ID         int32     `json:"id"`
+	NoteID     int32     `json:"note_id"`
+	Body       string    `json:"body"`
+	Visibility string    `json:"visibility"`
+	EditedBy   string    `json:"edited_by"`
+	EditedAt   time.Time `json:"edited_at"`
+}
+
+type Rubricitem struct {
+	ID        int32   `json:"id"`
+	SubjectID int32   `json:"subject_id"`
+	Name      string  `json:"name"`
+	Weight    float64 `json:"weight"`
+	MaxPoints int32   `json:"max_points"`
+	Position  int32   `json:"position"`
 }
 
 type Student struct {
-	ID   int32  `json:"id"`
-	Name string `json:"name"`
+	ID            int32          `json:"id"`
+	Name          string         `json:"name"`
+	Class         sql.NullString `json:"class"`
+	Status        string         `json:"status"`
+	Email         sql.NullString `json:"email"`
+	PreferredName sql.NullString `json:"preferred_name"`
+	Pronouns      sql.NullString `json:"pronouns"`
+	Timezone      sql.NullString `json:"timezone"`
+	Locale        sql.NullString `json:"locale"`
+	EnrolledOn    sql.NullTime   `json:"enrolled_on"`
+	Bio           sql.NullString `json:"bio"`
+	AvatarUrl     sql.NullString `json:"avatar_url"`
+}
+
+type Studentbadge struct {
+	ID           int32         `json:"id"`
+	StudentID    int32         `json:"student_id"`
+	BadgeID      int32         `json:"badge_id"`
+	CompletionID sql.NullInt32 `json:"completion_id"`
+	AwardedAt    time.Time     `json:"awarded_at"`
+}
+
+type Studentchecklistitem struct {
+	ID              int32     `json:"id"`
+	StudentID       int32     `json:"student_id"`
+	ChecklistItemID int32     `json:"checklist_item_id"`
+	CheckedAt       time.Time `json:"checked_at"`
+}
+
+type Studentclasshistory struct {
+	ID        int32          `json:"id"`
+	StudentID int32          `json:"student_id"`
+	FromClass sql.NullString `json:"from_class"`
+	ToClass   sql.NullString `json:"to_class"`
+	Reason    string         `json:"reason"`
+	ChangedBy string         `json:"changed_by"`
+	ChangedAt time.Time      `json:"changed_at"`
 }
 
 type Studentdiscord struct {
@@ -25,26 +197,98 @@ type Studentdiscord struct {
 	CreatedAt sql.NullTime `json:"created_at"`
 }
 
+type Studentgoal struct {
+	ID           int32          `json:"id"`
+	StudentID    int32          `json:"student_id"`
+	SubjectID    sql.NullInt32  `json:"subject_id"`
+	Class        sql.NullString `json:"class"`
+	TargetDate   time.Time      `json:"target_date"`
+	SetByTutorID sql.NullInt32  `json:"set_by_tutor_id"`
+	SetBy        string         `json:"set_by"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+type Studentlearningpath struct {
+	ID         int32     `json:"id"`
+	StudentID  int32     `json:"student_id"`
+	PathID     int32     `json:"path_id"`
+	EnrolledAt time.Time `json:"enrolled_at"`
+}
+
+type Studentriskscore struct {
+	StudentID int32     `json:"student_id"`
+	Level     string    `json:"level"`
+	Score     int32     `json:"score"`
+	Detail    string    `json:"detail"`
+	ScoredAt  time.Time `json:"scored_at"`
+}
+
+type Studentstatustransition struct {
+	ID               int32         `json:"id"`
+	StudentID        int32         `json:"student_id"`
+	FromStatus       string        `json:"from_status"`
+	ToStatus         string        `json:"to_status"`
+	Reason           string        `json:"reason"`
+	ChangedByTutorID sql.NullInt32 `json:"changed_by_tutor_id"`
+	ChangedBy        string        `json:"changed_by"`
+	ChangedAt        time.Time     `json:"changed_at"`
+}
+
 type Studentsubjectcompletion struct {
-	ID          int32        `json:"id"`
-	StudentID   int32        `json:"student_id"`
-	SubjectID   int32        `json:"subject_id"`
-	CompletedAt sql.NullTime `json:"completed_at"`
+	ID                int32           `json:"id"`
+	StudentID         int32           `json:"student_id"`
+	SubjectID         int32           `json:"subject_id"`
+	CompletedAt       sql.NullTime    `json:"completed_at"`
+	Grade             sql.NullFloat64 `json:"grade"`
+	Passed            bool            `json:"passed"`
+	RecordedByTutorID sql.NullInt32   `json:"recorded_by_tutor_id"`
+	RecordedBy        string          `json:"recorded_by"`
+	RecordedAt        time.Time       `json:"recorded_at"`
+	RevisionID        sql.NullInt32   `json:"revision_id"`
+	TermID            sql.NullInt32   `json:"term_id"`
+}
+
+type Studentsubjectstatus struct {
+	ID            int32          `json:"id"`
+	StudentID     int32          `json:"student_id"`
+	SubjectID     int32          `json:"subject_id"`
+	Status        string         `json:"status"`
+	StartedAt     sql.NullTime   `json:"started_at"`
+	BlockedReason sql.NullString `json:"blocked_reason"`
+	UpdatedAt     time.Time      `json:"updated_at"`
 }
 
 type Studenttutor struct {
-	ID        int32     `json:"id"`
-	StudentID int32     `json:"student_id"`
-	TutorID   int32     `json:"tutor_id"`
-	CreatedAt time.Time `json:"created_at"`
+	ID            int32          `json:"id"`
+	StudentID     int32          `json:"student_id"`
+	TutorID       int32          `json:"tutor_id"`
+	CreatedAt     time.Time      `json:"created_at"`
+	TermID        sql.NullInt32  `json:"term_id"`
+	EndedAt       sql.NullTime   `json:"ended_at"`
+	EndReason     sql.NullString `json:"end_reason"`
+	HandoffNotes  sql.NullString `json:"handoff_notes"`
+	EndedBy       sql.NullString `json:"ended_by"`
+	Active        sql.NullBool   `json:"active"`
+	Role          string         `json:"role"`
+	ActivePrimary sql.NullBool   `json:"active_primary"`
+}
+
+type Studysession struct {
+	ID        int32        `json:"id"`
+	StudentID int32        `json:"student_id"`
+	SubjectID int32        `json:"subject_id"`
+	StartedAt time.Time    `json:"started_at"`
+	StoppedAt sql.NullTime `json:"stopped_at"`
 }
 
 type Subject struct {
-	ID          int32          `json:"id"`
-	Code        string         `json:"code"`
-	Name        string         `json:"name"`
-	Description sql.NullString `json:"description"`
-	Class       string         `json:"class"`
+	ID           int32           `json:"id"`
+	Code         string          `json:"code"`
+	Name         string          `json:"name"`
+	Description  sql.NullString  `json:"description"`
+	Class        string          `json:"class"`
+	PassingGrade sql.NullFloat64 `json:"passing_grade"`
+	AutoComplete bool            `json:"auto_complete"`
 }
 
 type Subjectcategory struct {
@@ -53,11 +297,58 @@ type Subjectcategory struct {
 	CategoryID int32 `json:"category_id"`
 }
 
+type Subjectrevision struct {
+	ID            int32          `json:"id"`
+	SubjectID     int32          `json:"subject_id"`
+	Revision      int32          `json:"revision"`
+	Code          string         `json:"code"`
+	Name          string         `json:"name"`
+	Description   sql.NullString `json:"description"`
+	Class         string         `json:"class"`
+	EffectiveFrom time.Time      `json:"effective_from"`
+	ChangedBy     string         `json:"changed_by"`
+}
+
+type Term struct {
+	ID       int32     `json:"id"`
+	Name     string    `json:"name"`
+	StartsOn time.Time `json:"starts_on"`
+	EndsOn   time.Time `json:"ends_on"`
+}
+
 type Tutor struct {
-	ID        int32  `json:"id"`
-	Name      string `json:"name"`
-	ChannelID string `json:"channel_id"`
-	RoleID    string `json:"role_id"`
+	ID            int32          `json:"id"`
+	Name          string         `json:"name"`
+	ChannelID     string         `json:"channel_id"`
+	RoleID        string         `json:"role_id"`
+	Email         sql.NullString `json:"email"`
+	PreferredName sql.NullString `json:"preferred_name"`
+	Pronouns      sql.NullString `json:"pronouns"`
+	Timezone      sql.NullString `json:"timezone"`
+	Locale        sql.NullString `json:"locale"`
+	EnrolledOn    sql.NullTime   `json:"enrolled_on"`
+	Bio           sql.NullString `json:"bio"`
+	AvatarUrl     sql.NullString `json:"avatar_url"`
+	MaxStudents   sql.NullInt32  `json:"max_students"`
+	SupervisorID  sql.NullInt32  `json:"supervisor_id"`
+}
+
+type Tutorabsence struct {
+	ID           int32         `json:"id"`
+	TutorID      int32         `json:"tutor_id"`
+	SubstituteID sql.NullInt32 `json:"substitute_id"`
+	StartsAt     time.Time     `json:"starts_at"`
+	EndsAt       time.Time     `json:"ends_at"`
+	Reason       string        `json:"reason"`
+	CreatedBy    string        `json:"created_by"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+type Tutorcategory struct {
+	ID          int32 `json:"id"`
+	TutorID     int32 `json:"tutor_id"`
+	CategoryID  int32 `json:"category_id"`
+	Proficiency int32 `json:"proficiency"`
 }
 
 type Tutordiscord struct {
@@ -65,3 +356,18 @@ type Tutordiscord struct {
 	DiscordID string       `json:"discord_id"`
 	CreatedAt sql.NullTime `json:"created_at"`
 }
+
+type Tutorrequest struct {
+	ID             int32          `json:"id"`
+	StudentID      int32          `json:"student_id"`
+	TutorID        sql.NullInt32  `json:"tutor_id"`
+	CategoryID     sql.NullInt32  `json:"category_id"`
+	Message        sql.NullString `json:"message"`
+	Status         string         `json:"status"`
+	DecisionReason sql.NullString `json:"decision_reason"`
+	DecidedBy      sql.NullString `json:"decided_by"`
+	DecidedAt      sql.NullTime   `json:"decided_at"`
+	AssignmentID   sql.NullInt32  `json:"assignment_id"`
+	CreatedAt      time.Time      `json:"created_at"`
+	ExpiresAt      time.Time      `json:"expires_at"`
+}
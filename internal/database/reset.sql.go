@@ -9,6 +9,15 @@ import (
 	"context"
 )
 
+const resetBadges = `-- name: ResetBadges :exec
+delete from Badges
+`
+
+func (q *Queries) ResetBadges(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, resetBadges)
+	return err
+}
+
 const resetCategories = `-- name: ResetCategories :exec
 delete from Categories
 `
@@ -18,6 +27,33 @@ func (q *Queries) ResetCategories(ctx context.Context) error {
 	return err
 }
 
+const resetClasses = `-- name: ResetClasses :exec
+delete from Classes
+`
+
+func (q *Queries) ResetClasses(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, resetClasses)
+	return err
+}
+
+const resetEvents = `-- name: ResetEvents :exec
+delete from Events
+`
+
+func (q *Queries) ResetEvents(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, resetEvents)
+	return err
+}
+
+const resetLearningPaths = `-- name: ResetLearningPaths :exec
+delete from LearningPaths
+`
+
+func (q *Queries) ResetLearningPaths(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, resetLearningPaths)
+	return err
+}
+
 const resetSC = `-- name: ResetSC :exec
 delete from SubjectCategory
 `
@@ -81,6 +117,15 @@ func (q *Queries) ResetTD(ctx context.Context) error {
 	return err
 }
 
+const resetTerms = `-- name: ResetTerms :exec
+delete from Terms
+`
+
+func (q *Queries) ResetTerms(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, resetTerms)
+	return err
+}
+
 const resetTutors = `-- name: ResetTutors :exec
 delete from Tutors
 `
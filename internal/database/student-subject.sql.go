@@ -10,18 +10,62 @@ import (
 	"database/sql"
 )
 
+const createCompletionAmendment = `-- name: CreateCompletionAmendment :execresult
+INSERT INTO CompletionAmendments (completion_id, field, old_value, new_value, reason, amended_by_tutor_id, amended_by)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateCompletionAmendmentParams struct {
+	CompletionID     int32          `json:"completion_id"`
+	Field            string         `json:"field"`
+	OldValue         sql.NullString `json:"old_value"`
+	NewValue         sql.NullString `json:"new_value"`
+	Reason           string         `json:"reason"`
+	AmendedByTutorID sql.NullInt32  `json:"amended_by_tutor_id"`
+	AmendedBy        string         `json:"amended_by"`
+}
+
+func (q *Queries) CreateCompletionAmendment(ctx context.Context, arg CreateCompletionAmendmentParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, createCompletionAmendment,
+		arg.CompletionID,
+		arg.Field,
+		arg.OldValue,
+		arg.NewValue,
+		arg.Reason,
+		arg.AmendedByTutorID,
+		arg.AmendedBy,
+	)
+}
+
 const createStudentSubjectCompletion = `-- name: CreateStudentSubjectCompletion :execresult
-INSERT INTO StudentSubjectCompletion (student_id, subject_id)
-VALUES (?, ?)
+INSERT INTO StudentSubjectCompletion (student_id, subject_id, completed_at, grade, passed, recorded_by_tutor_id, recorded_by, revision_id, term_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type CreateStudentSubjectCompletionParams struct {
-	StudentID int32 `json:"student_id"`
-	SubjectID int32 `json:"subject_id"`
+	StudentID         int32           `json:"student_id"`
+	SubjectID         int32           `json:"subject_id"`
+	CompletedAt       sql.NullTime    `json:"completed_at"`
+	Grade             sql.NullFloat64 `json:"grade"`
+	Passed            bool            `json:"passed"`
+	RecordedByTutorID sql.NullInt32   `json:"recorded_by_tutor_id"`
+	RecordedBy        string          `json:"recorded_by"`
+	RevisionID        sql.NullInt32   `json:"revision_id"`
+	TermID            sql.NullInt32   `json:"term_id"`
 }
 
 func (q *Queries) CreateStudentSubjectCompletion(ctx context.Context, arg CreateStudentSubjectCompletionParams) (sql.Result, error) {
-	return q.db.ExecContext(ctx, createStudentSubjectCompletion, arg.StudentID, arg.SubjectID)
+	return q.db.ExecContext(ctx, createStudentSubjectCompletion,
+		arg.StudentID,
+		arg.SubjectID,
+		arg.CompletedAt,
+		arg.Grade,
+		arg.Passed,
+		arg.RecordedByTutorID,
+		arg.RecordedBy,
+		arg.RevisionID,
+		arg.TermID,
+	)
 }
 
 const deleteStudentSubjectCompletion = `-- name: DeleteStudentSubjectCompletion :exec
@@ -35,7 +79,7 @@ func (q *Queries) DeleteStudentSubjectCompletion(ctx context.Context, id int32)
 }
 
 const getStudentSubjectCompletionByID = `-- name: GetStudentSubjectCompletionByID :one
-SELECT id, student_id, subject_id, completed_at FROM StudentSubjectCompletion
+SELECT id, student_id, subject_id, completed_at, grade, passed, recorded_by_tutor_id, recorded_by, recorded_at, revision_id, term_id FROM StudentSubjectCompletion
 WHERE id = ?
 `
 
@@ -47,12 +91,87 @@ func (q *Queries) GetStudentSubjectCompletionByID(ctx context.Context, id int32)
 		&i.StudentID,
 		&i.SubjectID,
 		&i.CompletedAt,
+		&i.Grade,
+		&i.Passed,
+		&i.RecordedByTutorID,
+		&i.RecordedBy,
+		&i.RecordedAt,
+		&i.RevisionID,
+		&i.TermID,
 	)
 	return i, err
 }
 
+const getStudentSubjectCompletionByStudentAndSubject = `-- name: GetStudentSubjectCompletionByStudentAndSubject :one
+SELECT id, student_id, subject_id, completed_at, grade, passed, recorded_by_tutor_id, recorded_by, recorded_at, revision_id, term_id FROM StudentSubjectCompletion
+WHERE student_id = ? AND subject_id = ?
+`
+
+type GetStudentSubjectCompletionByStudentAndSubjectParams struct {
+	StudentID int32 `json:"student_id"`
+	SubjectID int32 `json:"subject_id"`
+}
+
+func (q *Queries) GetStudentSubjectCompletionByStudentAndSubject(ctx context.Context, arg GetStudentSubjectCompletionByStudentAndSubjectParams) (Studentsubjectcompletion, error) {
+	row := q.db.QueryRowContext(ctx, getStudentSubjectCompletionByStudentAndSubject, arg.StudentID, arg.SubjectID)
+	var i Studentsubjectcompletion
+	err := row.Scan(
+		&i.ID,
+		&i.StudentID,
+		&i.SubjectID,
+		&i.CompletedAt,
+		&i.Grade,
+		&i.Passed,
+		&i.RecordedByTutorID,
+		&i.RecordedBy,
+		&i.RecordedAt,
+		&i.RevisionID,
+		&i.TermID,
+	)
+	return i, err
+}
+
+const listCompletionAmendments = `-- name: ListCompletionAmendments :many
+SELECT id, completion_id, field, old_value, new_value, reason, amended_by_tutor_id, amended_by, created_at FROM CompletionAmendments
+WHERE completion_id = ?
+ORDER BY created_at, id
+`
+
+func (q *Queries) ListCompletionAmendments(ctx context.Context, completionID int32) ([]Completionamendment, error) {
+	rows, err := q.db.QueryContext(ctx, listCompletionAmendments, completionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Completionamendment{}
+	for rows.Next() {
+		var i Completionamendment
+		if err := rows.Scan(
+			&i.ID,
+			&i.CompletionID,
+			&i.Field,
+			&i.OldValue,
+			&i.NewValue,
+			&i.Reason,
+			&i.AmendedByTutorID,
+			&i.AmendedBy,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listStudentSubjectCompletions = `-- name: ListStudentSubjectCompletions :many
-SELECT id, student_id, subject_id, completed_at FROM StudentSubjectCompletion
+SELECT id, student_id, subject_id, completed_at, grade, passed, recorded_by_tutor_id, recorded_by, recorded_at, revision_id, term_id FROM StudentSubjectCompletion
 `
 
 func (q *Queries) ListStudentSubjectCompletions(ctx context.Context) ([]Studentsubjectcompletion, error) {
@@ -69,6 +188,13 @@ func (q *Queries) ListStudentSubjectCompletions(ctx context.Context) ([]Students
 			&i.StudentID,
 			&i.SubjectID,
 			&i.CompletedAt,
+			&i.Grade,
+			&i.Passed,
+			&i.RecordedByTutorID,
+			&i.RecordedBy,
+			&i.RecordedAt,
+			&i.RevisionID,
+			&i.TermID,
 		); err != nil {
 			return nil, err
 		}
@@ -84,7 +210,7 @@ func (q *Queries) ListStudentSubjectCompletions(ctx context.Context) ([]Students
 }
 
 const listStudentSubjectCompletionsByStudent = `-- name: ListStudentSubjectCompletionsByStudent :many
-SELECT id, student_id, subject_id, completed_at FROM StudentSubjectCompletion
+SELECT id, student_id, subject_id, completed_at, grade, passed, recorded_by_tutor_id, recorded_by, recorded_at, revision_id, term_id FROM StudentSubjectCompletion
 WHERE student_id = ?
 `
 
@@ -102,6 +228,13 @@ func (q *Queries) ListStudentSubjectCompletionsByStudent(ctx context.Context, st
 			&i.StudentID,
 			&i.SubjectID,
 			&i.CompletedAt,
+			&i.Grade,
+			&i.Passed,
+			&i.RecordedByTutorID,
+			&i.RecordedBy,
+			&i.RecordedAt,
+			&i.RevisionID,
+			&i.TermID,
 		); err != nil {
 			return nil, err
 		}
@@ -117,7 +250,7 @@ func (q *Queries) ListStudentSubjectCompletionsByStudent(ctx context.Context, st
 }
 
 const listStudentSubjectCompletionsBySubject = `-- name: ListStudentSubjectCompletionsBySubject :many
-SELECT id, student_id, subject_id, completed_at FROM StudentSubjectCompletion
+SELECT id, student_id, subject_id, completed_at, grade, passed, recorded_by_tutor_id, recorded_by, recorded_at, revision_id, term_id FROM StudentSubjectCompletion
 WHERE subject_id = ?
 `
 
@@ -135,6 +268,13 @@ func (q *Queries) ListStudentSubjectCompletionsBySubject(ctx context.Context, su
 			&i.StudentID,
 			&i.SubjectID,
 			&i.CompletedAt,
+			&i.Grade,
+			&i.Passed,
+			&i.RecordedByTutorID,
+			&i.RecordedBy,
+			&i.RecordedAt,
+			&i.RevisionID,
+			&i.TermID,
 		); err != nil {
 			return nil, err
 		}
@@ -148,3 +288,68 @@ func (q *Queries) ListStudentSubjectCompletionsBySubject(ctx context.Context, su
 	}
 	return items, nil
 }
+
+const updateStudentSubjectCompletionDate = `-- name: UpdateStudentSubjectCompletionDate :exec
+UPDATE StudentSubjectCompletion
+SET completed_at = ?
+WHERE id = ?
+`
+
+type UpdateStudentSubjectCompletionDateParams struct {
+	CompletedAt sql.NullTime `json:"completed_at"`
+	ID          int32        `json:"id"`
+}
+
+func (q *Queries) UpdateStudentSubjectCompletionDate(ctx context.Context, arg UpdateStudentSubjectCompletionDateParams) error {
+	_, err := q.db.ExecContext(ctx, updateStudentSubjectCompletionDate, arg.CompletedAt, arg.ID)
+	return err
+}
+
+const updateStudentSubjectCompletionGrade = `-- name: UpdateStudentSubjectCompletionGrade :exec
+UPDATE StudentSubjectCompletion
+SET grade = ?, passed = ?
+WHERE id = ?
+`
+
+type UpdateStudentSubjectCompletionGradeParams struct {
+	Grade  sql.NullFloat64 `json:"grade"`
+	Passed bool            `json:"passed"`
+	ID     int32           `json:"id"`
+}
+
+func (q *Queries) UpdateStudentSubjectCompletionGrade(ctx context.Context, arg UpdateStudentSubjectCompletionGradeParams) error {
+	_, err := q.db.ExecContext(ctx, updateStudentSubjectCompletionGrade, arg.Grade, arg.Passed, arg.ID)
+	return err
+}
+
+const updateStudentSubjectCompletionRevision = `-- name: UpdateStudentSubjectCompletionRevision :exec
+UPDATE StudentSubjectCompletion
+SET revision_id = ?
+WHERE id = ?
+`
+
+type UpdateStudentSubjectCompletionRevisionParams struct {
+	RevisionID sql.NullInt32 `json:"revision_id"`
+	ID         int32         `json:"id"`
+}
+
+func (q *Queries) UpdateStudentSubjectCompletionRevision(ctx context.Context, arg UpdateStudentSubjectCompletionRevisionParams) error {
+	_, err := q.db.ExecContext(ctx, updateStudentSubjectCompletionRevision, arg.RevisionID, arg.ID)
+	return err
+}
+
+const updateStudentSubjectCompletionTerm = `-- name: UpdateStudentSubjectCompletionTerm :exec
+UPDATE StudentSubjectCompletion
+SET term_id = ?
+WHERE id = ?
+`
+
+type UpdateStudentSubjectCompletionTermParams struct {
+	TermID sql.NullInt32 `json:"term_id"`
+	ID     int32         `json:"id"`
+}
+
+func (q *Queries) UpdateStudentSubjectCompletionTerm(ctx context.Context, arg UpdateStudentSubjectCompletionTermParams) error {
+	_, err := q.db.ExecContext(ctx, updateStudentSubjectCompletionTerm, arg.TermID, arg.ID)
+	return err
+}
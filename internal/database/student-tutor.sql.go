@@ -8,37 +8,107 @@ package database
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
+const countStudentTutorsByTutor = `-- name: CountStudentTutorsByTutor :one
+select count(*)
+from StudentTutor
+where tutor_id = ? and ended_at is null
+`
+
+func (q *Queries) CountStudentTutorsByTutor(ctx context.Context, tutorID int32) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countStudentTutorsByTutor, tutorID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createStudentTutor = `-- name: CreateStudentTutor :execresult
-insert into StudentTutor (student_id, tutor_id)
-values (?, ?)
+insert into StudentTutor (student_id, tutor_id, term_id, role)
+values (?, ?, ?, ?)
 `
 
 type CreateStudentTutorParams struct {
-	StudentID int32 `json:"student_id"`
-	TutorID   int32 `json:"tutor_id"`
+	StudentID int32         `json:"student_id"`
+	TutorID   int32         `json:"tutor_id"`
+	TermID    sql.NullInt32 `json:"term_id"`
+	Role      string        `json:"role"`
 }
 
 func (q *Queries) CreateStudentTutor(ctx context.Context, arg CreateStudentTutorParams) (sql.Result, error) {
-	return q.db.ExecContext(ctx, createStudentTutor, arg.StudentID, arg.TutorID)
+	return q.db.ExecContext(ctx, createStudentTutor,
+		arg.StudentID,
+		arg.TutorID,
+		arg.TermID,
+		arg.Role,
+	)
 }
 
-const deleteStudentTutorByID = `-- name: DeleteStudentTutorByID :exec
-delete from
-    StudentTutor
+const endStudentTutor = `-- name: EndStudentTutor :execresult
+update StudentTutor
+set ended_at = ?,
+    end_reason = ?,
+    handoff_notes = ?,
+    ended_by = ?
 where
-    id = ?
+    id = ? and ended_at is null
 `
 
-func (q *Queries) DeleteStudentTutorByID(ctx context.Context, id int32) error {
-	_, err := q.db.ExecContext(ctx, deleteStudentTutorByID, id)
-	return err
+type EndStudentTutorParams struct {
+	EndedAt      sql.NullTime   `json:"ended_at"`
+	EndReason    sql.NullString `json:"end_reason"`
+	HandoffNotes sql.NullString `json:"handoff_notes"`
+	EndedBy      sql.NullString `json:"ended_by"`
+	ID           int32          `json:"id"`
+}
+
+func (q *Queries) EndStudentTutor(ctx context.Context, arg EndStudentTutorParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, endStudentTutor,
+		arg.EndedAt,
+		arg.EndReason,
+		arg.HandoffNotes,
+		arg.EndedBy,
+		arg.ID,
+	)
+}
+
+const getPrimaryTutorByStudent = `-- name: GetPrimaryTutorByStudent :one
+select
+    t.id, t.name, t.channel_id, t.role_id, t.email, t.preferred_name, t.pronouns,
+    t.timezone, t.locale, t.enrolled_on, t.bio, t.avatar_url, t.max_students, t.supervisor_id
+from
+    StudentTutor st
+join Tutors t on t.id = st.tutor_id
+where
+    st.student_id = ? and st.active_primary = true
+`
+
+func (q *Queries) GetPrimaryTutorByStudent(ctx context.Context, studentID int32) (Tutor, error) {
+	row := q.db.QueryRowContext(ctx, getPrimaryTutorByStudent, studentID)
+	var i Tutor
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.ChannelID,
+		&i.RoleID,
+		&i.Email,
+		&i.PreferredName,
+		&i.Pronouns,
+		&i.Timezone,
+		&i.Locale,
+		&i.EnrolledOn,
+		&i.Bio,
+		&i.AvatarUrl,
+		&i.MaxStudents,
+		&i.SupervisorID,
+	)
+	return i, err
 }
 
 const getStudentTutorByID = `-- name: GetStudentTutorByID :one
 select
-    id, student_id, tutor_id, created_at
+    id, student_id, tutor_id, created_at, term_id, ended_at, end_reason, handoff_notes, ended_by, active, role, active_primary
 from
     StudentTutor
 where
@@ -53,13 +123,81 @@ func (q *Queries) GetStudentTutorByID(ctx context.Context, id int32) (Studenttut
 		&i.StudentID,
 		&i.TutorID,
 		&i.CreatedAt,
+		&i.TermID,
+		&i.EndedAt,
+		&i.EndReason,
+		&i.HandoffNotes,
+		&i.EndedBy,
+		&i.Active,
+		&i.Role,
+		&i.ActivePrimary,
 	)
 	return i, err
 }
 
+const listStudentTutorHistory = `-- name: ListStudentTutorHistory :many
+select
+    st.id, st.tutor_id, t.name as tutor_name, st.role, st.term_id, st.created_at,
+    st.ended_at, st.end_reason, st.handoff_notes, st.ended_by
+from
+    StudentTutor st
+join Tutors t on t.id = st.tutor_id
+where
+    st.student_id = ?
+ORDER BY
+    st.created_at, st.id
+`
+
+type ListStudentTutorHistoryRow struct {
+	ID           int32          `json:"id"`
+	TutorID      int32          `json:"tutor_id"`
+	TutorName    string         `json:"tutor_name"`
+	Role         string         `json:"role"`
+	TermID       sql.NullInt32  `json:"term_id"`
+	CreatedAt    time.Time      `json:"created_at"`
+	EndedAt      sql.NullTime   `json:"ended_at"`
+	EndReason    sql.NullString `json:"end_reason"`
+	HandoffNotes sql.NullString `json:"handoff_notes"`
+	EndedBy      sql.NullString `json:"ended_by"`
+}
+
+func (q *Queries) ListStudentTutorHistory(ctx context.Context, studentID int32) ([]ListStudentTutorHistoryRow, error) {
+	rows, err := q.db.QueryContext(ctx, listStudentTutorHistory, studentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListStudentTutorHistoryRow{}
+	for rows.Next() {
+		var i ListStudentTutorHistoryRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TutorID,
+			&i.TutorName,
+			&i.Role,
+			&i.TermID,
+			&i.CreatedAt,
+			&i.EndedAt,
+			&i.EndReason,
+			&i.HandoffNotes,
+			&i.EndedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listStudentTutors = `-- name: ListStudentTutors :many
 SELECT
-    id, student_id, tutor_id, created_at
+    id, student_id, tutor_id, created_at, term_id, ended_at, end_reason, handoff_notes, ended_by, active, role, active_primary
 FROM
     StudentTutor
 ORDER BY
@@ -80,6 +218,14 @@ func (q *Queries) ListStudentTutors(ctx context.Context) ([]Studenttutor, error)
 			&i.StudentID,
 			&i.TutorID,
 			&i.CreatedAt,
+			&i.TermID,
+			&i.EndedAt,
+			&i.EndReason,
+			&i.HandoffNotes,
+			&i.EndedBy,
+			&i.Active,
+			&i.Role,
+			&i.ActivePrimary,
 		); err != nil {
 			return nil, err
 		}
@@ -96,7 +242,7 @@ func (q *Queries) ListStudentTutors(ctx context.Context) ([]Studenttutor, error)
 
 const listStudentTutorsByStudent = `-- name: ListStudentTutorsByStudent :many
 select
-    id, student_id, tutor_id, created_at
+    id, student_id, tutor_id, created_at, term_id, ended_at, end_reason, handoff_notes, ended_by, active, role, active_primary
 from
     StudentTutor
 where
@@ -119,6 +265,14 @@ func (q *Queries) ListStudentTutorsByStudent(ctx context.Context, studentID int3
 			&i.StudentID,
 			&i.TutorID,
 			&i.CreatedAt,
+			&i.TermID,
+			&i.EndedAt,
+			&i.EndReason,
+			&i.HandoffNotes,
+			&i.EndedBy,
+			&i.Active,
+			&i.Role,
+			&i.ActivePrimary,
 		); err != nil {
 			return nil, err
 		}
@@ -135,7 +289,7 @@ func (q *Queries) ListStudentTutorsByStudent(ctx context.Context, studentID int3
 
 const listStudentTutorsByTutor = `-- name: ListStudentTutorsByTutor :many
 select
-    id, student_id, tutor_id, created_at
+    id, student_id, tutor_id, created_at, term_id, ended_at, end_reason, handoff_notes, ended_by, active, role, active_primary
 from
     StudentTutor
 where
@@ -158,6 +312,14 @@ func (q *Queries) ListStudentTutorsByTutor(ctx context.Context, tutorID int32) (
 			&i.StudentID,
 			&i.TutorID,
 			&i.CreatedAt,
+			&i.TermID,
+			&i.EndedAt,
+			&i.EndReason,
+			&i.HandoffNotes,
+			&i.EndedBy,
+			&i.Active,
+			&i.Role,
+			&i.ActivePrimary,
 		); err != nil {
 			return nil, err
 		}
@@ -171,3 +333,18 @@ func (q *Queries) ListStudentTutorsByTutor(ctx context.Context, tutorID int32) (
 	}
 	return items, nil
 }
+
+const updateStudentTutorRole = `-- name: UpdateStudentTutorRole :execresult
+update StudentTutor
+set role = ?
+where id = ? and ended_at is null
+`
+
+type UpdateStudentTutorRoleParams struct {
+	Role string `json:"role"`
+	ID   int32  `json:"id"`
+}
+
+func (q *Queries) UpdateStudentTutorRole(ctx context.Context, arg UpdateStudentTutorRoleParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, updateStudentTutorRole, arg.Role, arg.ID)
+}
@@ -11,15 +11,67 @@ import (
 )
 
 const createStudent = `-- name: CreateStudent :execresult
-insert into Students (name) value (?)
+insert into Students (name, class, status, email, preferred_name, pronouns, timezone, locale, enrolled_on, bio, avatar_url)
+value (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
-func (q *Queries) CreateStudent(ctx context.Context, name string) (sql.Result, error) {
-	return q.db.ExecContext(ctx, createStudent, name)
+type CreateStudentParams struct {
+	Name          string         `json:"name"`
+	Class         sql.NullString `json:"class"`
+	Status        string         `json:"status"`
+	Email         sql.NullString `json:"email"`
+	PreferredName sql.NullString `json:"preferred_name"`
+	Pronouns      sql.NullString `json:"pronouns"`
+	Timezone      sql.NullString `json:"timezone"`
+	Locale        sql.NullString `json:"locale"`
+	EnrolledOn    sql.NullTime   `json:"enrolled_on"`
+	Bio           sql.NullString `json:"bio"`
+	AvatarUrl     sql.NullString `json:"avatar_url"`
+}
+
+func (q *Queries) CreateStudent(ctx context.Context, arg CreateStudentParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, createStudent,
+		arg.Name,
+		arg.Class,
+		arg.Status,
+		arg.Email,
+		arg.PreferredName,
+		arg.Pronouns,
+		arg.Timezone,
+		arg.Locale,
+		arg.EnrolledOn,
+		arg.Bio,
+		arg.AvatarUrl,
+	)
+}
+
+const createStudentStatusTransition = `-- name: CreateStudentStatusTransition :execresult
+insert into StudentStatusTransitions (student_id, from_status, to_status, reason, changed_by_tutor_id, changed_by)
+values (?, ?, ?, ?, ?, ?)
+`
+
+type CreateStudentStatusTransitionParams struct {
+	StudentID        int32         `json:"student_id"`
+	FromStatus       string        `json:"from_status"`
+	ToStatus         string        `json:"to_status"`
+	Reason           string        `json:"reason"`
+	ChangedByTutorID sql.NullInt32 `json:"changed_by_tutor_id"`
+	ChangedBy        string        `json:"changed_by"`
+}
+
+func (q *Queries) CreateStudentStatusTransition(ctx context.Context, arg CreateStudentStatusTransitionParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, createStudentStatusTransition,
+		arg.StudentID,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.Reason,
+		arg.ChangedByTutorID,
+		arg.ChangedBy,
+	)
 }
 
 const getAllStudents = `-- name: GetAllStudents :many
-select id, name from Students
+select id, name, class, status, email, preferred_name, pronouns, timezone, locale, enrolled_on, bio, avatar_url from Students
 `
 
 func (q *Queries) GetAllStudents(ctx context.Context) ([]Student, error) {
@@ -31,7 +83,61 @@ func (q *Queries) GetAllStudents(ctx context.Context) ([]Student, error) {
 	items := []Student{}
 	for rows.Next() {
 		var i Student
-		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Class,
+			&i.Status,
+			&i.Email,
+			&i.PreferredName,
+			&i.Pronouns,
+			&i.Timezone,
+			&i.Locale,
+			&i.EnrolledOn,
+			&i.Bio,
+			&i.AvatarUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllStudentsByStatus = `-- name: GetAllStudentsByStatus :many
+select id, name, class, status, email, preferred_name, pronouns, timezone, locale, enrolled_on, bio, avatar_url from Students
+where status = ?
+`
+
+func (q *Queries) GetAllStudentsByStatus(ctx context.Context, status string) ([]Student, error) {
+	rows, err := q.db.QueryContext(ctx, getAllStudentsByStatus, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Student{}
+	for rows.Next() {
+		var i Student
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Class,
+			&i.Status,
+			&i.Email,
+			&i.PreferredName,
+			&i.Pronouns,
+			&i.Timezone,
+			&i.Locale,
+			&i.EnrolledOn,
+			&i.Bio,
+			&i.AvatarUrl,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -46,7 +152,7 @@ func (q *Queries) GetAllStudents(ctx context.Context) ([]Student, error) {
 }
 
 const getAllStudentsWithNameLike = `-- name: GetAllStudentsWithNameLike :many
-select id, name from Students
+select id, name, class, status, email, preferred_name, pronouns, timezone, locale, enrolled_on, bio, avatar_url from Students
 where name like ?
 `
 
@@ -59,7 +165,20 @@ func (q *Queries) GetAllStudentsWithNameLike(ctx context.Context, name string) (
 	items := []Student{}
 	for rows.Next() {
 		var i Student
-		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Class,
+			&i.Status,
+			&i.Email,
+			&i.PreferredName,
+			&i.Pronouns,
+			&i.Timezone,
+			&i.Locale,
+			&i.EnrolledOn,
+			&i.Bio,
+			&i.AvatarUrl,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -74,17 +193,68 @@ func (q *Queries) GetAllStudentsWithNameLike(ctx context.Context, name string) (
 }
 
 const getStudentByID = `-- name: GetStudentByID :one
-select id, name from Students
+select id, name, class, status, email, preferred_name, pronouns, timezone, locale, enrolled_on, bio, avatar_url from Students
 where id = ?
 `
 
 func (q *Queries) GetStudentByID(ctx context.Context, id int32) (Student, error) {
 	row := q.db.QueryRowContext(ctx, getStudentByID, id)
 	var i Student
-	err := row.Scan(&i.ID, &i.Name)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Class,
+		&i.Status,
+		&i.Email,
+		&i.PreferredName,
+		&i.Pronouns,
+		&i.Timezone,
+		&i.Locale,
+		&i.EnrolledOn,
+		&i.Bio,
+		&i.AvatarUrl,
+	)
 	return i, err
 }
 
+const listStudentStatusTransitions = `-- name: ListStudentStatusTransitions :many
+select id, student_id, from_status, to_status, reason, changed_by_tutor_id, changed_by, changed_at from StudentStatusTransitions
+where student_id = ?
+order by changed_at, id
+`
+
+func (q *Queries) ListStudentStatusTransitions(ctx context.Context, studentID int32) ([]Studentstatustransition, error) {
+	rows, err := q.db.QueryContext(ctx, listStudentStatusTransitions, studentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Studentstatustransition{}
+	for rows.Next() {
+		var i Studentstatustransition
+		if err := rows.Scan(
+			&i.ID,
+			&i.StudentID,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.Reason,
+			&i.ChangedByTutorID,
+			&i.ChangedBy,
+			&i.ChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateStudent = `-- name: UpdateStudent :execresult
 update Students
 set name = ?
@@ -99,3 +269,68 @@ type UpdateStudentParams struct {
 func (q *Queries) UpdateStudent(ctx context.Context, arg UpdateStudentParams) (sql.Result, error) {
 	return q.db.ExecContext(ctx, updateStudent, arg.Name, arg.ID)
 }
+
+const updateStudentClass = `-- name: UpdateStudentClass :exec
+update Students
+set class = ?
+where id = ?
+`
+
+type UpdateStudentClassParams struct {
+	Class sql.NullString `json:"class"`
+	ID    int32          `json:"id"`
+}
+
+func (q *Queries) UpdateStudentClass(ctx context.Context, arg UpdateStudentClassParams) error {
+	_, err := q.db.ExecContext(ctx, updateStudentClass, arg.Class, arg.ID)
+	return err
+}
+
+const updateStudentProfile = `-- name: UpdateStudentProfile :exec
+update Students
+set email = ?, preferred_name = ?, pronouns = ?, timezone = ?, locale = ?, enrolled_on = ?, bio = ?, avatar_url = ?
+where id = ?
+`
+
+type UpdateStudentProfileParams struct {
+	Email         sql.NullString `json:"email"`
+	PreferredName sql.NullString `json:"preferred_name"`
+	Pronouns      sql.NullString `json:"pronouns"`
+	Timezone      sql.NullString `json:"timezone"`
+	Locale        sql.NullString `json:"locale"`
+	EnrolledOn    sql.NullTime   `json:"enrolled_on"`
+	Bio           sql.NullString `json:"bio"`
+	AvatarUrl     sql.NullString `json:"avatar_url"`
+	ID            int32          `json:"id"`
+}
+
+func (q *Queries) UpdateStudentProfile(ctx context.Context, arg UpdateStudentProfileParams) error {
+	_, err := q.db.ExecContext(ctx, updateStudentProfile,
+		arg.Email,
+		arg.PreferredName,
+		arg.Pronouns,
+		arg.Timezone,
+		arg.Locale,
+		arg.EnrolledOn,
+		arg.Bio,
+		arg.AvatarUrl,
+		arg.ID,
+	)
+	return err
+}
+
+const updateStudentStatus = `-- name: UpdateStudentStatus :execresult
+update Students
+set status = ?
+where id = ? and status = ?
+`
+
+type UpdateStudentStatusParams struct {
+	ToStatus   string `json:"to_status"`
+	ID         int32  `json:"id"`
+	FromStatus string `json:"from_status"`
+}
+
+func (q *Queries) UpdateStudentStatus(ctx context.Context, arg UpdateStudentStatusParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, updateStudentStatus, arg.ToStatus, arg.ID, arg.FromStatus)
+}
@@ -62,3 +62,30 @@ func (q *Queries) ListCategoriesBySubjectID(ctx context.Context, subjectID int32
 	}
 	return items, nil
 }
+
+const listSubjectCategories = `-- name: ListSubjectCategories :many
+select id, subject_id, category_id from SubjectCategory
+`
+
+func (q *Queries) ListSubjectCategories(ctx context.Context) ([]Subjectcategory, error) {
+	rows, err := q.db.QueryContext(ctx, listSubjectCategories)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Subjectcategory{}
+	for rows.Next() {
+		var i Subjectcategory
+		if err := rows.Scan(&i.ID, &i.SubjectID, &i.CategoryID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
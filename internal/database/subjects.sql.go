@@ -32,7 +32,7 @@ func (q *Queries) CreateSubject(ctx context.Context, arg CreateSubjectParams) (s
 }
 
 const getSubjectByID = `-- name: GetSubjectByID :one
-select id, code, name, description, class from Subjects
+select id, code, name, description, class, passing_grade, auto_complete from Subjects
 where id = ?
 `
 
@@ -45,12 +45,14 @@ func (q *Queries) GetSubjectByID(ctx context.Context, id int32) (Subject, error)
 		&i.Name,
 		&i.Description,
 		&i.Class,
+		&i.PassingGrade,
+		&i.AutoComplete,
 	)
 	return i, err
 }
 
 const listSubjects = `-- name: ListSubjects :many
-select id, code, name, description, class from Subjects
+select id, code, name, description, class, passing_grade, auto_complete from Subjects
 order by code
 `
 
@@ -69,6 +71,8 @@ func (q *Queries) ListSubjects(ctx context.Context) ([]Subject, error) {
 			&i.Name,
 			&i.Description,
 			&i.Class,
+			&i.PassingGrade,
+			&i.AutoComplete,
 		); err != nil {
 			return nil, err
 		}
@@ -84,7 +88,7 @@ func (q *Queries) ListSubjects(ctx context.Context) ([]Subject, error) {
 }
 
 const listSubjectsByClass = `-- name: ListSubjectsByClass :many
-select id, code, name, description, class from Subjects
+select id, code, name, description, class, passing_grade, auto_complete from Subjects
 where class = ?
 order by code
 `
@@ -104,6 +108,8 @@ func (q *Queries) ListSubjectsByClass(ctx context.Context, class string) ([]Subj
 			&i.Name,
 			&i.Description,
 			&i.Class,
+			&i.PassingGrade,
+			&i.AutoComplete,
 		); err != nil {
 			return nil, err
 		}
@@ -141,3 +147,35 @@ func (q *Queries) UpdateSubject(ctx context.Context, arg UpdateSubjectParams) (s
 		arg.ID,
 	)
 }
+
+const updateSubjectAutoComplete = `-- name: UpdateSubjectAutoComplete :exec
+update Subjects
+set auto_complete = ?
+where id = ?
+`
+
+type UpdateSubjectAutoCompleteParams struct {
+	AutoComplete bool  `json:"auto_complete"`
+	ID           int32 `json:"id"`
+}
+
+func (q *Queries) UpdateSubjectAutoComplete(ctx context.Context, arg UpdateSubjectAutoCompleteParams) error {
+	_, err := q.db.ExecContext(ctx, updateSubjectAutoComplete, arg.AutoComplete, arg.ID)
+	return err
+}
+
+const updateSubjectPassingGrade = `-- name: UpdateSubjectPassingGrade :exec
+update Subjects
+set passing_grade = ?
+where id = ?
+`
+
+type UpdateSubjectPassingGradeParams struct {
+	PassingGrade sql.NullFloat64 `json:"passing_grade"`
+	ID           int32           `json:"id"`
+}
+
+func (q *Queries) UpdateSubjectPassingGrade(ctx context.Context, arg UpdateSubjectPassingGradeParams) error {
+	_, err := q.db.ExecContext(ctx, updateSubjectPassingGrade, arg.PassingGrade, arg.ID)
+	return err
+}
@@ -11,21 +11,42 @@ import (
 )
 
 const createTutor = `-- name: CreateTutor :execresult
-insert into Tutors (name, role_id, channel_id) value (?, ?, ?)
+insert into Tutors (name, role_id, channel_id, email, preferred_name, pronouns, timezone, locale, enrolled_on, bio, avatar_url)
+value (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type CreateTutorParams struct {
-	Name      string `json:"name"`
-	RoleID    string `json:"role_id"`
-	ChannelID string `json:"channel_id"`
+	Name          string         `json:"name"`
+	RoleID        string         `json:"role_id"`
+	ChannelID     string         `json:"channel_id"`
+	Email         sql.NullString `json:"email"`
+	PreferredName sql.NullString `json:"preferred_name"`
+	Pronouns      sql.NullString `json:"pronouns"`
+	Timezone      sql.NullString `json:"timezone"`
+	Locale        sql.NullString `json:"locale"`
+	EnrolledOn    sql.NullTime   `json:"enrolled_on"`
+	Bio           sql.NullString `json:"bio"`
+	AvatarUrl     sql.NullString `json:"avatar_url"`
 }
 
 func (q *Queries) CreateTutor(ctx context.Context, arg CreateTutorParams) (sql.Result, error) {
-	return q.db.ExecContext(ctx, createTutor, arg.Name, arg.RoleID, arg.ChannelID)
+	return q.db.ExecContext(ctx, createTutor,
+		arg.Name,
+		arg.RoleID,
+		arg.ChannelID,
+		arg.Email,
+		arg.PreferredName,
+		arg.Pronouns,
+		arg.Timezone,
+		arg.Locale,
+		arg.EnrolledOn,
+		arg.Bio,
+		arg.AvatarUrl,
+	)
 }
 
 const getAllTutors = `-- name: GetAllTutors :many
-select id, name, channel_id, role_id from Tutors
+select id, name, channel_id, role_id, email, preferred_name, pronouns, timezone, locale, enrolled_on, bio, avatar_url, max_students, supervisor_id from Tutors
 `
 
 func (q *Queries) GetAllTutors(ctx context.Context) ([]Tutor, error) {
@@ -42,6 +63,16 @@ func (q *Queries) GetAllTutors(ctx context.Context) ([]Tutor, error) {
 			&i.Name,
 			&i.ChannelID,
 			&i.RoleID,
+			&i.Email,
+			&i.PreferredName,
+			&i.Pronouns,
+			&i.Timezone,
+			&i.Locale,
+			&i.EnrolledOn,
+			&i.Bio,
+			&i.AvatarUrl,
+			&i.MaxStudents,
+			&i.SupervisorID,
 		); err != nil {
 			return nil, err
 		}
@@ -57,7 +88,7 @@ func (q *Queries) GetAllTutors(ctx context.Context) ([]Tutor, error) {
 }
 
 const getAllTutorsWithNameLike = `-- name: GetAllTutorsWithNameLike :many
-select id, name, channel_id, role_id from Tutors
+select id, name, channel_id, role_id, email, preferred_name, pronouns, timezone, locale, enrolled_on, bio, avatar_url, max_students, supervisor_id from Tutors
 where name like ?
 `
 
@@ -75,6 +106,16 @@ func (q *Queries) GetAllTutorsWithNameLike(ctx context.Context, name string) ([]
 			&i.Name,
 			&i.ChannelID,
 			&i.RoleID,
+			&i.Email,
+			&i.PreferredName,
+			&i.Pronouns,
+			&i.Timezone,
+			&i.Locale,
+			&i.EnrolledOn,
+			&i.Bio,
+			&i.AvatarUrl,
+			&i.MaxStudents,
+			&i.SupervisorID,
 		); err != nil {
 			return nil, err
 		}
@@ -90,7 +131,7 @@ func (q *Queries) GetAllTutorsWithNameLike(ctx context.Context, name string) ([]
 }
 
 const getTutorByID = `-- name: GetTutorByID :one
-select id, name, channel_id, role_id from Tutors
+select id, name, channel_id, role_id, email, preferred_name, pronouns, timezone, locale, enrolled_on, bio, avatar_url, max_students, supervisor_id from Tutors
 where id = ?
 `
 
@@ -102,10 +143,101 @@ func (q *Queries) GetTutorByID(ctx context.Context, id int32) (Tutor, error) {
 		&i.Name,
 		&i.ChannelID,
 		&i.RoleID,
+		&i.Email,
+		&i.PreferredName,
+		&i.Pronouns,
+		&i.Timezone,
+		&i.Locale,
+		&i.EnrolledOn,
+		&i.Bio,
+		&i.AvatarUrl,
+		&i.MaxStudents,
+		&i.SupervisorID,
 	)
 	return i, err
 }
 
+const lockTutorCapacities = `-- name: LockTutorCapacities :many
+select id, max_students from Tutors
+order by id
+for update
+`
+
+type LockTutorCapacitiesRow struct {
+	ID          int32         `json:"id"`
+	MaxStudents sql.NullInt32 `json:"max_students"`
+}
+
+func (q *Queries) LockTutorCapacities(ctx context.Context) ([]LockTutorCapacitiesRow, error) {
+	rows, err := q.db.QueryContext(ctx, lockTutorCapacities)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []LockTutorCapacitiesRow{}
+	for rows.Next() {
+		var i LockTutorCapacitiesRow
+		if err := rows.Scan(&i.ID, &i.MaxStudents); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockTutorCapacity = `-- name: LockTutorCapacity :one
+select max_students from Tutors
+where id = ?
+for update
+`
+
+func (q *Queries) LockTutorCapacity(ctx context.Context, id int32) (sql.NullInt32, error) {
+	row := q.db.QueryRowContext(ctx, lockTutorCapacity, id)
+	var max_students sql.NullInt32
+	err := row.Scan(&max_students)
+	return max_students, err
+}
+
+const lockTutorSupervisors = `-- name: LockTutorSupervisors :many
+select id, supervisor_id from Tutors
+order by id
+for update
+`
+
+type LockTutorSupervisorsRow struct {
+	ID           int32         `json:"id"`
+	SupervisorID sql.NullInt32 `json:"supervisor_id"`
+}
+
+func (q *Queries) LockTutorSupervisors(ctx context.Context) ([]LockTutorSupervisorsRow, error) {
+	rows, err := q.db.QueryContext(ctx, lockTutorSupervisors)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []LockTutorSupervisorsRow{}
+	for rows.Next() {
+		var i LockTutorSupervisorsRow
+		if err := rows.Scan(&i.ID, &i.SupervisorID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateTutor = `-- name: UpdateTutor :execresult
 update Tutors
 set name = ?,
@@ -129,3 +261,68 @@ func (q *Queries) UpdateTutor(ctx context.Context, arg UpdateTutorParams) (sql.R
 		arg.ID,
 	)
 }
+
+const updateTutorCapacity = `-- name: UpdateTutorCapacity :exec
+update Tutors
+set max_students = ?
+where id = ?
+`
+
+type UpdateTutorCapacityParams struct {
+	MaxStudents sql.NullInt32 `json:"max_students"`
+	ID          int32         `json:"id"`
+}
+
+func (q *Queries) UpdateTutorCapacity(ctx context.Context, arg UpdateTutorCapacityParams) error {
+	_, err := q.db.ExecContext(ctx, updateTutorCapacity, arg.MaxStudents, arg.ID)
+	return err
+}
+
+const updateTutorProfile = `-- name: UpdateTutorProfile :exec
+update Tutors
+set email = ?, preferred_name = ?, pronouns = ?, timezone = ?, locale = ?, enrolled_on = ?, bio = ?, avatar_url = ?
+where id = ?
+`
+
+type UpdateTutorProfileParams struct {
+	Email         sql.NullString `json:"email"`
+	PreferredName sql.NullString `json:"preferred_name"`
+	Pronouns      sql.NullString `json:"pronouns"`
+	Timezone      sql.NullString `json:"timezone"`
+	Locale        sql.NullString `json:"locale"`
+	EnrolledOn    sql.NullTime   `json:"enrolled_on"`
+	Bio           sql.NullString `json:"bio"`
+	AvatarUrl     sql.NullString `json:"avatar_url"`
+	ID            int32          `json:"id"`
+}
+
+func (q *Queries) UpdateTutorProfile(ctx context.Context, arg UpdateTutorProfileParams) error {
+	_, err := q.db.ExecContext(ctx, updateTutorProfile,
+		arg.Email,
+		arg.PreferredName,
+		arg.Pronouns,
+		arg.Timezone,
+		arg.Locale,
+		arg.EnrolledOn,
+		arg.Bio,
+		arg.AvatarUrl,
+		arg.ID,
+	)
+	return err
+}
+
+const updateTutorSupervisor = `-- name: UpdateTutorSupervisor :exec
+update Tutors
+set supervisor_id = ?
+where id = ?
+`
+
+type UpdateTutorSupervisorParams struct {
+	SupervisorID sql.NullInt32 `json:"supervisor_id"`
+	ID           int32         `json:"id"`
+}
+
+func (q *Queries) UpdateTutorSupervisor(ctx context.Context, arg UpdateTutorSupervisorParams) error {
+	_, err := q.db.ExecContext(ctx, updateTutorSupervisor, arg.SupervisorID, arg.ID)
+	return err
+}
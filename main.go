@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"net/http"
 	"os"
 
@@ -22,20 +23,92 @@ func main() {
 	mux.HandleFunc("/reset", cfg.ResetHandler)
 	mux.HandleFunc("/subjects", cfg.SubjectHandler)
 	mux.HandleFunc("/subjects/{id}", cfg.SubjectByIDHandler)
+	mux.HandleFunc("/subjects/{id}/rubric", cfg.SubjectRubricHandler)
+	mux.HandleFunc("/subjects/{id}/grades", cfg.SubjectGradesHandler)
+	mux.HandleFunc("/subjects/{id}/history", cfg.SubjectHistoryHandler)
+	mux.HandleFunc("/subjects/{id}/checklist", cfg.SubjectChecklistHandler)
+	mux.HandleFunc("/categories", cfg.CategoriesHandler)
+	mux.HandleFunc("/categories/tree", cfg.CategoryTreeHandler)
+	mux.HandleFunc("/categories/{id}", cfg.CategoryByIDHandler)
+	mux.HandleFunc("/badges", cfg.BadgesHandler)
+	mux.HandleFunc("/badges/{id}", cfg.BadgeByIDHandler)
+	mux.HandleFunc("/classes", cfg.ClassesHandler)
+	mux.HandleFunc("/classes/{id}", cfg.ClassByIDHandler)
+	mux.HandleFunc("/classes/{id}/rules", cfg.ClassRulesHandler)
+	mux.HandleFunc("/classes/{id}/rules/{rule_id}", cfg.ClassRuleByIDHandler)
+	mux.HandleFunc("/terms", cfg.TermsHandler)
+	mux.HandleFunc("/terms/{id}", cfg.TermByIDHandler)
+	mux.HandleFunc("/cohorts", cfg.CohortsHandler)
+	mux.HandleFunc("/cohorts/{id}", cfg.CohortByIDHandler)
+	mux.HandleFunc("/cohorts/{id}/students", cfg.CohortStudentsHandler)
+	mux.HandleFunc("/cohorts/{id}/students/{student_id}", cfg.CohortStudentByIDHandler)
+	mux.HandleFunc("/learning-paths", cfg.LearningPathsHandler)
+	mux.HandleFunc("/learning-paths/{id}", cfg.LearningPathByIDHandler)
+	mux.HandleFunc("/learning-paths/{id}/students", cfg.LearningPathStudentsHandler)
+	mux.HandleFunc("/learning-paths/{id}/students/{student_id}", cfg.LearningPathStudentByIDHandler)
 	mux.HandleFunc("/tutors", cfg.TutorsHandler)
 	mux.HandleFunc("/tutors/{id}", cfg.TutorsByIdHandler)
+	mux.HandleFunc("/tutors/{id}/profile", cfg.TutorProfileHandler)
+	mux.HandleFunc("/tutors/{id}/expertise", cfg.TutorExpertiseHandler)
+	mux.HandleFunc("/tutors/{id}/capacity", cfg.TutorCapacityHandler)
+	mux.HandleFunc("/tutors/{id}/absences", cfg.TutorAbsencesHandler)
+	mux.HandleFunc("/tutors/{id}/absences/{absence_id}", cfg.TutorAbsenceByIDHandler)
+	mux.HandleFunc("/tutors/{id}/supervisor", cfg.TutorSupervisorHandler)
+	mux.HandleFunc("/tutors/{id}/team-report", cfg.TutorTeamReportHandler)
+	mux.HandleFunc("/tutors/{id}/overdue", cfg.TutorOverdueHandler)
 	mux.HandleFunc("/students", cfg.StudentsHandler)
 	mux.HandleFunc("/students/{id}", cfg.StudentsByIdHandler)
+	mux.HandleFunc("/students/{id}/checklist", cfg.StudentChecklistHandler)
+	mux.HandleFunc("/students/{id}/checklist/{item_id}", cfg.StudentChecklistItemHandler)
+	mux.HandleFunc("/students/{id}/class", cfg.StudentClassHandler)
+	mux.HandleFunc("/students/{id}/status", cfg.StudentStatusHandler)
+	mux.HandleFunc("/students/{id}/profile", cfg.StudentProfileHandler)
+	mux.HandleFunc("/students/{id}/tutor-suggestions", cfg.StudentTutorSuggestionsHandler)
+	mux.HandleFunc("/students/{id}/auto-assign", cfg.StudentAutoAssignHandler)
+	mux.HandleFunc("/students/{id}/tutor-history", cfg.StudentTutorHistoryHandler)
+	mux.HandleFunc("/students/{id}/discord-routing", cfg.StudentDiscordRoutingHandler)
+	mux.HandleFunc("/students/{id}/notes", cfg.StudentNotesHandler)
+	mux.HandleFunc("/students/{id}/export", cfg.StudentExportHandler)
+	mux.HandleFunc("/students/{id}/goals", cfg.StudentGoalsHandler)
+	mux.HandleFunc("/students/{id}/goals/{goal_id}", cfg.StudentGoalByIDHandler)
+	mux.HandleFunc("/students/{id}/recommendations", cfg.StudentRecommendationsHandler)
+	mux.HandleFunc("/students/{id}/badges", cfg.StudentBadgesHandler)
+	mux.HandleFunc("/students/{id}/progression", cfg.StudentProgressionHandler)
+	mux.HandleFunc("/students/{id}/learning-paths", cfg.StudentLearningPathsHandler)
+	mux.HandleFunc("/students/{id}/learning-paths/next", cfg.StudentNextSubjectsHandler)
+	mux.HandleFunc("/tutor-requests", cfg.TutorRequestsHandler)
+	mux.HandleFunc("/tutor-requests/{id}", cfg.TutorRequestByIDHandler)
+	mux.HandleFunc("/tutor-requests/{id}/accept", cfg.TutorRequestAcceptHandler)
+	mux.HandleFunc("/tutor-requests/{id}/decline", cfg.TutorRequestDeclineHandler)
 	mux.HandleFunc("/students-tutors", cfg.StudentTutorHandler)
 	mux.HandleFunc("/students-tutors/{id}", cfg.StudentTutorByIDHandler)
 	mux.HandleFunc("/students-subjects", cfg.StudentSubjectsHandler)
 	mux.HandleFunc("/students-subjects/{id}", cfg.StudentSubjectsByIDHandler)
+	mux.HandleFunc("/students-subjects/{id}/scores", cfg.CompletionScoresHandler)
+	mux.HandleFunc("/students-subjects/{id}/amendments", cfg.CompletionAmendmentsHandler)
+	mux.HandleFunc("/students-subjects/status", cfg.StudentSubjectStatusHandler)
+	mux.HandleFunc("/students-subjects/stats", cfg.StudentSubjectStatsHandler)
+	mux.HandleFunc("/study-sessions", cfg.StudySessionsHandler)
+	mux.HandleFunc("/study-sessions/{id}", cfg.StudySessionByIDHandler)
+	mux.HandleFunc("/notes", cfg.NotesHandler)
+	mux.HandleFunc("/notes/{id}", cfg.NoteByIDHandler)
+	mux.HandleFunc("/notes/{id}/history", cfg.NoteHistoryHandler)
+	mux.HandleFunc("/events", cfg.EventsHandler)
+	mux.HandleFunc("/help-requests", cfg.HelpRequestsHandler)
+	mux.HandleFunc("/help-requests/stats", cfg.HelpStatsHandler)
+	mux.HandleFunc("/help-requests/{id}", cfg.HelpRequestByIDHandler)
+	mux.HandleFunc("/help-requests/{id}/{action}", cfg.HelpRequestActionHandler)
+	mux.HandleFunc("/help-queue", cfg.HelpQueueHandler)
+	mux.HandleFunc("/help-queue/stream", cfg.HelpQueueStreamHandler)
+	mux.HandleFunc("/reports/at-risk", cfg.AtRiskReportHandler)
 	
 	mux.HandleFunc("/student-discords", cfg.StudentDiscordsHandler)
 	mux.HandleFunc("/student-discords/{id}", cfg.StudentDiscordByIDHandler)
 	mux.HandleFunc("/tutor-discords", cfg.TutorDiscordsHandler)
 	mux.HandleFunc("/tutor-discords/{id}", cfg.TutorDiscordByIDHandler)
 
+	go cfg.RunRiskScoring(context.Background())
+
 	fmt.Printf("listening on http://localhost:8080\n")
 	http.ListenAndServe(":8080", mux)
 	
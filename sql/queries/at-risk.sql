@@ -0,0 +1,10 @@
+-- name: DeleteStudentRiskScores :exec
+delete from StudentRiskScores;
+
+-- name: CreateStudentRiskScore :exec
+insert into StudentRiskScores (student_id, level, score, detail, scored_at)
+values (?, ?, ?, ?, ?);
+
+-- name: ListStudentRiskScores :many
+select * from StudentRiskScores
+order by score desc, student_id;
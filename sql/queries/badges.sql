@@ -0,0 +1,31 @@
+-- name: ListBadges :many
+select * from Badges
+order by id;
+
+-- name: GetBadgeByID :one
+select * from Badges
+where id = ?;
+
+-- name: CreateBadge :execresult
+insert into Badges (code, name, description, rule, category_id, threshold, announce)
+values (?, ?, ?, ?, ?, ?, ?);
+
+-- name: UpdateBadge :exec
+update Badges
+set code = ?, name = ?, description = ?, rule = ?, category_id = ?, threshold = ?, announce = ?
+where id = ?;
+
+-- name: DeleteBadge :exec
+delete from Badges
+where id = ?;
+
+-- name: AwardBadge :execresult
+insert ignore into StudentBadges (student_id, badge_id, completion_id)
+values (?, ?, ?);
+
+-- name: ListStudentBadges :many
+select sb.id, sb.badge_id, b.code, b.name, b.description, sb.completion_id, sb.awarded_at
+from StudentBadges sb
+join Badges b on b.id = sb.badge_id
+where sb.student_id = ?
+order by sb.awarded_at, sb.id;
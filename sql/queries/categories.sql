@@ -1,16 +1,19 @@
 -- name: GetCategoryByName :one
-select id, name from Categories
+select id, name, parent_id from Categories
 where name = ?;
 
 -- name: CreateCategory :execresult
 insert into Categories (name) values (?);
 
+-- name: CreateCategoryWithParent :execresult
+insert into Categories (name, parent_id) values (?, ?);
+
 -- name: GetCategoryByID :one
-select id, name from Categories
+select id, name, parent_id from Categories
 where id = ?;
 
 -- name: GetAllCategories :many
-select id, name from Categories
+select id, name, parent_id from Categories
 order by name;
 
 -- name: UpdateCategory :execresult
@@ -18,7 +21,11 @@ update Categories
 set name = ?
 where id = ?;
 
+-- name: UpdateCategoryParent :exec
+update Categories
+set parent_id = ?
+where id = ?;
+
 -- name: DeleteCategory :execresult
 delete from Categories
 where id = ?;
-
@@ -0,0 +1,49 @@
+-- name: ListChecklistItemsBySubject :many
+select * from ChecklistItems
+where subject_id = ?
+order by position, id;
+
+-- name: GetChecklistItemByID :one
+select * from ChecklistItems
+where id = ?;
+
+-- name: UpsertChecklistItem :exec
+insert into ChecklistItems (subject_id, label, required, position)
+values (?, ?, ?, ?)
+on duplicate key update
+required = values(required),
+position = values(position);
+
+-- name: DeleteChecklistItem :exec
+delete from ChecklistItems
+where id = ?;
+
+-- name: CheckStudentChecklistItem :exec
+insert ignore into StudentChecklistItems (student_id, checklist_item_id)
+values (?, ?);
+
+-- name: UncheckStudentChecklistItem :exec
+delete from StudentChecklistItems
+where student_id = ? and checklist_item_id = ?;
+
+-- name: ListStudentChecklistBySubject :many
+select ci.id, ci.subject_id, ci.label, ci.required, ci.position, sci.checked_at
+from ChecklistItems ci
+left join StudentChecklistItems sci
+    on sci.checklist_item_id = ci.id and sci.student_id = ?
+where ci.subject_id = ?
+order by ci.position, ci.id;
+
+-- name: ListChecklistSubjectsByStudent :many
+select distinct ci.subject_id
+from StudentChecklistItems sci
+join ChecklistItems ci on sci.checklist_item_id = ci.id
+where sci.student_id = ?
+order by ci.subject_id;
+
+-- name: CountUncheckedRequiredItems :one
+select count(*)
+from ChecklistItems ci
+left join StudentChecklistItems sci
+    on sci.checklist_item_id = ci.id and sci.student_id = ?
+where ci.subject_id = ? and ci.required = true and sci.id is null;
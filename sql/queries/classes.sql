@@ -0,0 +1,35 @@
+-- name: ListClasses :many
+select * from Classes
+order by position, code;
+
+-- name: GetClassByID :one
+select * from Classes
+where id = ?;
+
+-- name: GetClassByCode :one
+select * from Classes
+where code = ?;
+
+-- name: CreateClass :execresult
+insert into Classes (code, name, position, description)
+values (?, ?, ?, ?);
+
+-- name: UpdateClass :execresult
+update Classes
+set code = ?, name = ?, position = ?, description = ?
+where id = ?;
+
+-- name: DeleteClass :exec
+delete from Classes
+where id = ?;
+
+
+-- name: GetNextClass :one
+select * from Classes
+where position > ?
+order by position, code
+limit 1;
+
+-- name: GetLastClassPosition :one
+select cast(coalesce(max(position), 0) as signed) as position
+from Classes;
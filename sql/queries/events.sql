@@ -0,0 +1,26 @@
+-- name: CreateEvent :execresult
+insert into Events (kind, student_id, payload)
+values (?, ?, ?);
+
+-- name: ListEventsAfter :many
+select * from Events
+where id > ?
+order by id
+limit ?;
+
+-- name: ListEventsByKindAfter :many
+select * from Events
+where kind = ? and id > ?
+order by id
+limit ?;
+
+-- name: ListEventsByKindPrefixAfter :many
+select * from Events
+where kind like ? and id > ?
+order by id
+limit ?;
+
+-- name: GetLastEventID :one
+select id from Events
+order by id desc
+limit 1;
@@ -0,0 +1,25 @@
+-- name: CreateStudentGoal :execresult
+insert into StudentGoals (student_id, subject_id, class, target_date, set_by_tutor_id, set_by)
+values (?, ?, ?, ?, ?, ?);
+
+-- name: GetStudentGoalByID :one
+select * from StudentGoals
+where id = ?;
+
+-- name: ListStudentGoals :many
+select * from StudentGoals
+where student_id = ?
+order by target_date, id;
+
+-- name: ListAllStudentGoals :many
+select * from StudentGoals
+order by student_id, target_date, id;
+
+-- name: UpdateStudentGoalTarget :exec
+update StudentGoals
+set target_date = ?
+where id = ?;
+
+-- name: DeleteStudentGoal :exec
+delete from StudentGoals
+where id = ?;
@@ -0,0 +1,46 @@
+-- name: CreateHelpRequest :execresult
+insert into HelpRequests (student_id, subject_id, message, priority)
+values (?, ?, ?, ?);
+
+-- name: GetHelpRequestByID :one
+select * from HelpRequests
+where id = ?;
+
+-- name: ListHelpRequests :many
+select * from HelpRequests
+order by id;
+
+-- name: ListHelpRequestsByStatus :many
+select * from HelpRequests
+where status = ?
+order by priority desc, queued_at, id;
+
+-- name: ListActiveHelpRequestsByStudent :many
+select * from HelpRequests
+where student_id = ? and status in ('open', 'claimed');
+
+-- name: ClaimHelpRequest :execresult
+update HelpRequests
+set status = 'claimed',
+    tutor_id = ?,
+    claimed_at = ?,
+    wait_seconds = wait_seconds + TIMESTAMPDIFF(SECOND, queued_at, claimed_at)
+where id = ? and status = 'open';
+
+-- name: ReleaseHelpRequest :execresult
+update HelpRequests
+set status = 'open',
+    queued_at = ?,
+    handle_seconds = handle_seconds + TIMESTAMPDIFF(SECOND, claimed_at, queued_at),
+    tutor_id = NULL,
+    claimed_at = NULL,
+    handbacks = handbacks + 1
+where id = ? and status = 'claimed' and tutor_id = ?;
+
+-- name: ResolveHelpRequest :execresult
+update HelpRequests
+set status = 'resolved',
+    resolved_at = ?,
+    handle_seconds = handle_seconds + TIMESTAMPDIFF(SECOND, claimed_at, resolved_at),
+    resolution = ?
+where id = ? and status = 'claimed' and tutor_id = ?;
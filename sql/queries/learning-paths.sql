@@ -0,0 +1,51 @@
+-- name: ListLearningPaths :many
+select * from LearningPaths
+order by name;
+
+-- name: GetLearningPathByID :one
+select * from LearningPaths
+where id = ?;
+
+-- name: CreateLearningPath :execresult
+insert into LearningPaths (name, description)
+values (?, ?);
+
+-- name: UpdateLearningPath :execresult
+update LearningPaths
+set name = ?, description = ?
+where id = ?;
+
+-- name: DeleteLearningPath :exec
+delete from LearningPaths
+where id = ?;
+
+-- name: ListLearningPathSubjects :many
+select * from LearningPathSubjects
+where path_id = ?
+order by position;
+
+-- name: CreateLearningPathSubject :execresult
+insert into LearningPathSubjects (path_id, subject_id, position)
+values (?, ?, ?);
+
+-- name: DeleteLearningPathSubjects :exec
+delete from LearningPathSubjects
+where path_id = ?;
+
+-- name: EnrollStudentInLearningPath :execresult
+insert into StudentLearningPaths (student_id, path_id)
+values (?, ?);
+
+-- name: UnenrollStudentFromLearningPath :execresult
+delete from StudentLearningPaths
+where student_id = ? and path_id = ?;
+
+-- name: ListLearningPathEnrollments :many
+select * from StudentLearningPaths
+where path_id = ?
+order by enrolled_at;
+
+-- name: ListStudentLearningPaths :many
+select * from StudentLearningPaths
+where student_id = ?
+order by enrolled_at;
@@ -0,0 +1,36 @@
+-- name: CreateNote :execresult
+insert into Notes (student_id, completion_id, author_tutor_id, author, visibility, body)
+values (?, ?, ?, ?, ?, ?);
+
+-- name: GetNoteByID :one
+select * from Notes
+where id = ?;
+
+-- name: ListNotesByStudent :many
+select * from Notes
+where student_id = ?
+order by created_at desc, id desc;
+
+-- name: SearchNotes :many
+select * from Notes
+where body like ?
+order by created_at desc, id desc;
+
+-- name: UpdateNote :exec
+update Notes
+set body = ?,
+    visibility = ?
+where id = ?;
+
+-- name: DeleteNote :exec
+delete from Notes
+where id = ?;
+
+-- name: CreateNoteRevision :execresult
+insert into NoteRevisions (note_id, body, visibility, edited_by)
+values (?, ?, ?, ?);
+
+-- name: ListNoteRevisions :many
+select * from NoteRevisions
+where note_id = ?
+order by edited_at, id;
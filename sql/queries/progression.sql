@@ -0,0 +1,25 @@
+-- name: ListProgressionRulesByClass :many
+select * from ClassProgressionRules
+where class_id = ?
+order by id;
+
+-- name: GetProgressionRuleByID :one
+select * from ClassProgressionRules
+where id = ?;
+
+-- name: CreateProgressionRule :execresult
+insert into ClassProgressionRules (class_id, kind, category_id, min_count)
+values (?, ?, ?, ?);
+
+-- name: DeleteProgressionRule :exec
+delete from ClassProgressionRules
+where id = ?;
+
+-- name: CreateStudentClassHistory :execresult
+insert into StudentClassHistory (student_id, from_class, to_class, reason, changed_by)
+values (?, ?, ?, ?, ?);
+
+-- name: ListStudentClassHistory :many
+select * from StudentClassHistory
+where student_id = ?
+order by changed_at, id;
@@ -15,4 +15,14 @@ delete from Students;
 -- name: ResetSubjects :exec
 delete from Subjects;
 -- name: ResetTutors :exec
-delete from Tutors;
\ No newline at end of file
+delete from Tutors;
+-- name: ResetClasses :exec
+delete from Classes;
+-- name: ResetTerms :exec
+delete from Terms;
+-- name: ResetEvents :exec
+delete from Events;
+-- name: ResetBadges :exec
+delete from Badges;
+-- name: ResetLearningPaths :exec
+delete from LearningPaths;
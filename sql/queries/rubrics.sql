@@ -0,0 +1,31 @@
+-- name: ListRubricItemsBySubject :many
+select * from RubricItems
+where subject_id = ?
+order by position, id;
+
+-- name: GetRubricItemByID :one
+select * from RubricItems
+where id = ?;
+
+-- name: UpsertRubricItem :exec
+insert into RubricItems (subject_id, name, weight, max_points, position)
+values (?, ?, ?, ?, ?)
+on duplicate key update
+weight = values(weight),
+max_points = values(max_points),
+position = values(position);
+
+-- name: DeleteRubricItem :exec
+delete from RubricItems
+where id = ?;
+
+-- name: CreateCompletionScore :execresult
+insert into CompletionScores (completion_id, rubric_item_id, points)
+values (?, ?, ?);
+
+-- name: ListCompletionScoresByCompletion :many
+select cs.id, cs.completion_id, cs.rubric_item_id, ri.name, ri.weight, ri.max_points, cs.points
+from CompletionScores cs
+join RubricItems ri on cs.rubric_item_id = ri.id
+where cs.completion_id = ?
+order by ri.position, ri.id;
@@ -0,0 +1,72 @@
+-- name: UpsertStudentSubjectStatus :exec
+insert into StudentSubjectStatus (student_id, subject_id, status, started_at, blocked_reason)
+values (?, ?, ?, ?, ?)
+on duplicate key update
+status = values(status),
+started_at = coalesce(started_at, values(started_at)),
+blocked_reason = values(blocked_reason);
+
+-- name: GetStudentSubjectStatus :one
+select * from StudentSubjectStatus
+where student_id = ? and subject_id = ?;
+
+-- name: ListStudentSubjectStatuses :many
+select * from StudentSubjectStatus
+order by student_id, subject_id;
+
+-- name: ListStudentSubjectStatusesByStudent :many
+select * from StudentSubjectStatus
+where student_id = ?
+order by subject_id;
+
+-- name: ListStudentSubjectStatusesBySubject :many
+select * from StudentSubjectStatus
+where subject_id = ?
+order by student_id;
+
+-- name: CreateStudySession :execresult
+insert into StudySessions (student_id, subject_id)
+values (?, ?);
+
+-- name: GetStudySessionByID :one
+select * from StudySessions
+where id = ?;
+
+-- name: GetOpenStudySession :one
+select * from StudySessions
+where student_id = ? and subject_id = ? and stopped_at is null
+limit 1;
+
+-- name: StopStudySession :exec
+update StudySessions
+set stopped_at = CURRENT_TIMESTAMP
+where id = ? and stopped_at is null;
+
+-- name: ListStudySessions :many
+select * from StudySessions
+order by started_at desc;
+
+-- name: ListStudySessionsByStudent :many
+select * from StudySessions
+where student_id = ?
+order by started_at desc;
+
+-- name: ListStudySessionsBySubject :many
+select * from StudySessions
+where subject_id = ?
+order by started_at desc;
+
+-- name: ListCompletionDurations :many
+select ssc.student_id, ssc.subject_id, s.class, sss.started_at, ssc.completed_at, ssc.term_id
+from StudentSubjectCompletion ssc
+join Subjects s on s.id = ssc.subject_id
+left join StudentSubjectStatus sss
+    on sss.student_id = ssc.student_id and sss.subject_id = ssc.subject_id
+order by ssc.subject_id;
+
+-- name: ListClosedStudySessions :many
+select ss.id, ss.student_id, ss.subject_id, s.class, ss.started_at, ss.stopped_at
+from StudySessions ss
+join Subjects s on s.id = ss.subject_id
+where ss.stopped_at is not null
+order by ss.subject_id;
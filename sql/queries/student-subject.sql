@@ -10,13 +10,46 @@ WHERE subject_id = ?;
 SELECT * FROM StudentSubjectCompletion;
 
 -- name: CreateStudentSubjectCompletion :execresult
-INSERT INTO StudentSubjectCompletion (student_id, subject_id)
-VALUES (?, ?);
+INSERT INTO StudentSubjectCompletion (student_id, subject_id, completed_at, grade, passed, recorded_by_tutor_id, recorded_by, revision_id, term_id)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
 
 -- name: GetStudentSubjectCompletionByID :one
 SELECT * FROM StudentSubjectCompletion
 WHERE id = ?;
 
+-- name: GetStudentSubjectCompletionByStudentAndSubject :one
+SELECT * FROM StudentSubjectCompletion
+WHERE student_id = ? AND subject_id = ?;
+
 -- name: DeleteStudentSubjectCompletion :exec
 DELETE FROM StudentSubjectCompletion
-WHERE id = ?;
\ No newline at end of file
+WHERE id = ?;
+
+-- name: UpdateStudentSubjectCompletionDate :exec
+UPDATE StudentSubjectCompletion
+SET completed_at = ?
+WHERE id = ?;
+
+-- name: UpdateStudentSubjectCompletionRevision :exec
+UPDATE StudentSubjectCompletion
+SET revision_id = ?
+WHERE id = ?;
+
+-- name: UpdateStudentSubjectCompletionTerm :exec
+UPDATE StudentSubjectCompletion
+SET term_id = ?
+WHERE id = ?;
+
+-- name: UpdateStudentSubjectCompletionGrade :exec
+UPDATE StudentSubjectCompletion
+SET grade = ?, passed = ?
+WHERE id = ?;
+
+-- name: CreateCompletionAmendment :execresult
+INSERT INTO CompletionAmendments (completion_id, field, old_value, new_value, reason, amended_by_tutor_id, amended_by)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListCompletionAmendments :many
+SELECT * FROM CompletionAmendments
+WHERE completion_id = ?
+ORDER BY created_at, id;
\ No newline at end of file
@@ -28,8 +28,23 @@ ORDER BY
     student_id;
 
 -- name: CreateStudentTutor :execresult
-insert into StudentTutor (student_id, tutor_id)
-values (?, ?);
+insert into StudentTutor (student_id, tutor_id, term_id, role)
+values (?, ?, ?, ?);
+
+-- name: UpdateStudentTutorRole :execresult
+update StudentTutor
+set role = ?
+where id = ? and ended_at is null;
+
+-- name: GetPrimaryTutorByStudent :one
+select
+    t.id, t.name, t.channel_id, t.role_id, t.email, t.preferred_name, t.pronouns,
+    t.timezone, t.locale, t.enrolled_on, t.bio, t.avatar_url, t.max_students, t.supervisor_id
+from
+    StudentTutor st
+join Tutors t on t.id = st.tutor_id
+where
+    st.student_id = ? and st.active_primary = true;
 
 -- name: GetStudentTutorByID :one
 select
@@ -39,8 +54,28 @@ from
 where
     id = ?;
 
--- name: DeleteStudentTutorByID :exec
-delete from
-    StudentTutor
+-- name: EndStudentTutor :execresult
+update StudentTutor
+set ended_at = ?,
+    end_reason = ?,
+    handoff_notes = ?,
+    ended_by = ?
 where
-    id = ?;
+    id = ? and ended_at is null;
+
+-- name: ListStudentTutorHistory :many
+select
+    st.id, st.tutor_id, t.name as tutor_name, st.role, st.term_id, st.created_at,
+    st.ended_at, st.end_reason, st.handoff_notes, st.ended_by
+from
+    StudentTutor st
+join Tutors t on t.id = st.tutor_id
+where
+    st.student_id = ?
+ORDER BY
+    st.created_at, st.id;
+
+-- name: CountStudentTutorsByTutor :one
+select count(*)
+from StudentTutor
+where tutor_id = ? and ended_at is null;
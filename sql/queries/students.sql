@@ -1,15 +1,16 @@
 -- name: CreateStudent :execresult
-insert into Students (name) value (?);
+insert into Students (name, class, status, email, preferred_name, pronouns, timezone, locale, enrolled_on, bio, avatar_url)
+value (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 
 -- name: GetStudentByID :one
-select id, name from Students
+select id, name, class, status, email, preferred_name, pronouns, timezone, locale, enrolled_on, bio, avatar_url from Students
 where id = ?;
 
 -- name: GetAllStudents :many
-select id, name from Students;
+select id, name, class, status, email, preferred_name, pronouns, timezone, locale, enrolled_on, bio, avatar_url from Students;
 
 -- name: GetAllStudentsWithNameLike :many
-select id, name from Students
+select id, name, class, status, email, preferred_name, pronouns, timezone, locale, enrolled_on, bio, avatar_url from Students
 where name like ?;
 
 -- name: UpdateStudent :execresult
@@ -17,3 +18,30 @@ update Students
 set name = ?
 where id = ?;
 
+-- name: UpdateStudentClass :exec
+update Students
+set class = ?
+where id = ?;
+
+-- name: UpdateStudentProfile :exec
+update Students
+set email = ?, preferred_name = ?, pronouns = ?, timezone = ?, locale = ?, enrolled_on = ?, bio = ?, avatar_url = ?
+where id = ?;
+
+-- name: GetAllStudentsByStatus :many
+select id, name, class, status, email, preferred_name, pronouns, timezone, locale, enrolled_on, bio, avatar_url from Students
+where status = ?;
+
+-- name: UpdateStudentStatus :execresult
+update Students
+set status = sqlc.arg(to_status)
+where id = sqlc.arg(id) and status = sqlc.arg(from_status);
+
+-- name: CreateStudentStatusTransition :execresult
+insert into StudentStatusTransitions (student_id, from_status, to_status, reason, changed_by_tutor_id, changed_by)
+values (?, ?, ?, ?, ?, ?);
+
+-- name: ListStudentStatusTransitions :many
+select * from StudentStatusTransitions
+where student_id = ?
+order by changed_at, id;
\ No newline at end of file
@@ -9,4 +9,7 @@ order by c.name;
 
 -- name: DeleteSubjectCategoriesBySubjectID :exec
 delete from SubjectCategory
-where subject_id = ?;
\ No newline at end of file
+where subject_id = ?;
+
+-- name: ListSubjectCategories :many
+select id, subject_id, category_id from SubjectCategory;
\ No newline at end of file
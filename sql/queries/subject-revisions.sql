@@ -0,0 +1,20 @@
+-- name: CreateSubjectRevision :execresult
+insert into SubjectRevisions (subject_id, revision, code, name, description, class, effective_from, changed_by)
+values (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListSubjectRevisions :many
+select * from SubjectRevisions
+where subject_id = ?
+order by revision;
+
+-- name: GetLatestSubjectRevision :one
+select * from SubjectRevisions
+where subject_id = ?
+order by revision desc
+limit 1;
+
+-- name: GetSubjectRevisionAt :one
+select * from SubjectRevisions
+where subject_id = ? and effective_from <= ?
+order by effective_from desc, revision desc
+limit 1;
@@ -3,19 +3,29 @@ insert into Subjects (code, name, description, class)
 values (?, ?, ?, ?);
 
 -- name: GetSubjectByID :one
-select id, code, name, description, class from Subjects
+select id, code, name, description, class, passing_grade, auto_complete from Subjects
 where id = ?;
 
 -- name: ListSubjects :many
-select id, code, name, description, class from Subjects
+select id, code, name, description, class, passing_grade, auto_complete from Subjects
 order by code;
 
 -- name: ListSubjectsByClass :many
-select id, code, name, description, class from Subjects
+select id, code, name, description, class, passing_grade, auto_complete from Subjects
 where class = ?
 order by code;
 
 -- name: UpdateSubject :execresult
 update Subjects
 set code = ?, name = ?, description = ?, class = ?
+where id = ?;
+
+-- name: UpdateSubjectPassingGrade :exec
+update Subjects
+set passing_grade = ?
+where id = ?;
+
+-- name: UpdateSubjectAutoComplete :exec
+update Subjects
+set auto_complete = ?
 where id = ?;
\ No newline at end of file
@@ -0,0 +1,70 @@
+-- name: ListTerms :many
+select * from Terms
+order by starts_on;
+
+-- name: GetTermByID :one
+select * from Terms
+where id = ?;
+
+-- name: GetTermAt :one
+select * from Terms
+where starts_on <= ? and ends_on >= ?
+order by starts_on desc
+limit 1;
+
+-- name: CreateTerm :execresult
+insert into Terms (name, starts_on, ends_on)
+values (?, ?, ?);
+
+-- name: UpdateTerm :execresult
+update Terms
+set name = ?, starts_on = ?, ends_on = ?
+where id = ?;
+
+-- name: DeleteTerm :exec
+delete from Terms
+where id = ?;
+
+-- name: ListCohorts :many
+select * from Cohorts
+order by term_id, name;
+
+-- name: ListCohortsByTerm :many
+select * from Cohorts
+where term_id = ?
+order by name;
+
+-- name: GetCohortByID :one
+select * from Cohorts
+where id = ?;
+
+-- name: CreateCohort :execresult
+insert into Cohorts (term_id, name)
+values (?, ?);
+
+-- name: UpdateCohort :execresult
+update Cohorts
+set name = ?
+where id = ?;
+
+-- name: DeleteCohort :exec
+delete from Cohorts
+where id = ?;
+
+-- name: ListCohortStudents :many
+select * from CohortStudents
+where cohort_id = ?
+order by student_id;
+
+-- name: AddCohortStudent :execresult
+insert into CohortStudents (cohort_id, student_id)
+values (?, ?);
+
+-- name: RemoveCohortStudent :execresult
+delete from CohortStudents
+where cohort_id = ? and student_id = ?;
+
+-- name: ListStudentIDsByTerm :many
+select distinct cs.student_id from CohortStudents cs
+join Cohorts co on co.id = cs.cohort_id
+where co.term_id = ?;
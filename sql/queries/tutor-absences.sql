@@ -0,0 +1,26 @@
+-- name: CreateTutorAbsence :execresult
+insert into TutorAbsences (tutor_id, substitute_id, starts_at, ends_at, reason, created_by)
+values (?, ?, ?, ?, ?, ?);
+
+-- name: GetTutorAbsenceByID :one
+select * from TutorAbsences
+where id = ?;
+
+-- name: ListTutorAbsencesByTutor :many
+select * from TutorAbsences
+where tutor_id = ?
+order by starts_at;
+
+-- name: ListAbsencesOverlapping :many
+select * from TutorAbsences
+where starts_at < ? and ends_at > ?
+order by starts_at;
+
+-- name: EndTutorAbsence :exec
+update TutorAbsences
+set ends_at = ?
+where id = ?;
+
+-- name: DeleteTutorAbsence :exec
+delete from TutorAbsences
+where id = ?;
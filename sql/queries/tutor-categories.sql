@@ -0,0 +1,16 @@
+-- name: ListTutorCategories :many
+select * from TutorCategories
+order by tutor_id, category_id;
+
+-- name: ListTutorCategoriesByTutor :many
+select * from TutorCategories
+where tutor_id = ?
+order by category_id;
+
+-- name: CreateTutorCategory :execresult
+insert into TutorCategories (tutor_id, category_id, proficiency)
+values (?, ?, ?);
+
+-- name: DeleteTutorCategoriesByTutor :exec
+delete from TutorCategories
+where tutor_id = ?;
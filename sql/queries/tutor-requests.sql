@@ -0,0 +1,34 @@
+-- name: CreateTutorRequest :execresult
+insert into TutorRequests (student_id, tutor_id, category_id, message, expires_at)
+values (?, ?, ?, ?, ?);
+
+-- name: GetTutorRequestByID :one
+select * from TutorRequests
+where id = ?;
+
+-- name: ListTutorRequests :many
+select * from TutorRequests
+order by created_at, id;
+
+-- name: ListPendingTutorRequestsByStudent :many
+select * from TutorRequests
+where student_id = ? and status = 'pending';
+
+-- name: DecideTutorRequest :execresult
+update TutorRequests
+set status = ?,
+    decision_reason = ?,
+    decided_by = ?,
+    decided_at = ?,
+    assignment_id = ?
+where id = ? and status = 'pending';
+
+-- name: ExpireTutorRequests :exec
+update TutorRequests
+set status = 'expired'
+where status = 'pending' and expires_at <= ?;
+
+-- name: LockTutorRequest :one
+select * from TutorRequests
+where id = ?
+for update;
@@ -1,5 +1,6 @@
 -- name: CreateTutor :execresult
-insert into Tutors (name, role_id, channel_id) value (?, ?, ?);
+insert into Tutors (name, role_id, channel_id, email, preferred_name, pronouns, timezone, locale, enrolled_on, bio, avatar_url)
+value (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 
 -- name: GetTutorByID :one
 select * from Tutors
@@ -19,3 +20,32 @@ set name = ?,
     channel_id = ?
 where id = ?;
 
+-- name: UpdateTutorProfile :exec
+update Tutors
+set email = ?, preferred_name = ?, pronouns = ?, timezone = ?, locale = ?, enrolled_on = ?, bio = ?, avatar_url = ?
+where id = ?;
+
+-- name: UpdateTutorCapacity :exec
+update Tutors
+set max_students = ?
+where id = ?;
+
+-- name: LockTutorCapacity :one
+select max_students from Tutors
+where id = ?
+for update;
+
+-- name: LockTutorCapacities :many
+select id, max_students from Tutors
+order by id
+for update;
+
+-- name: LockTutorSupervisors :many
+select id, supervisor_id from Tutors
+order by id
+for update;
+
+-- name: UpdateTutorSupervisor :exec
+update Tutors
+set supervisor_id = ?
+where id = ?;
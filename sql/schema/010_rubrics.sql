@@ -0,0 +1,56 @@
+-- +goose up
+CREATE TABLE RubricItems (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    subject_id INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    weight DOUBLE NOT NULL DEFAULT 1,
+    max_points INT NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+
+    -- A criterion name is unique inside a subject's rubric
+    UNIQUE KEY unique_subject_rubric_item (subject_id, name),
+
+    CONSTRAINT fk_ri_subject
+        FOREIGN KEY (subject_id)
+        REFERENCES Subjects(id)
+        ON DELETE CASCADE
+);
+
+CREATE TABLE CompletionScores (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    completion_id INT NOT NULL,
+    rubric_item_id INT NOT NULL,
+    points DOUBLE NOT NULL,
+
+    -- A completion is scored at most once per criterion
+    UNIQUE KEY unique_completion_rubric_item (completion_id, rubric_item_id),
+
+    CONSTRAINT fk_cs_completion
+        FOREIGN KEY (completion_id)
+        REFERENCES StudentSubjectCompletion(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_cs_rubric_item
+        FOREIGN KEY (rubric_item_id)
+        REFERENCES RubricItems(id)
+        ON DELETE CASCADE
+);
+
+-- Grades go from 0 to 100; a NULL passing_grade means every completion passes
+ALTER TABLE Subjects
+ADD COLUMN passing_grade DOUBLE;
+
+ALTER TABLE StudentSubjectCompletion
+ADD COLUMN grade DOUBLE,
+ADD COLUMN passed BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- +goose down
+ALTER TABLE StudentSubjectCompletion
+DROP COLUMN grade,
+DROP COLUMN passed;
+
+ALTER TABLE Subjects
+DROP COLUMN passing_grade;
+
+DROP TABLE IF EXISTS CompletionScores;
+DROP TABLE IF EXISTS RubricItems;
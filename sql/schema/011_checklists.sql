@@ -0,0 +1,46 @@
+-- +goose up
+CREATE TABLE ChecklistItems (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    subject_id INT NOT NULL,
+    label VARCHAR(255) NOT NULL,
+    required BOOLEAN NOT NULL DEFAULT TRUE,
+    position INT NOT NULL DEFAULT 0,
+
+    UNIQUE KEY unique_subject_checklist_label (subject_id, label),
+
+    CONSTRAINT fk_ci_subject
+        FOREIGN KEY (subject_id)
+        REFERENCES Subjects(id)
+        ON DELETE CASCADE
+);
+
+-- A row means the student has ticked the item
+CREATE TABLE StudentChecklistItems (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    student_id INT NOT NULL,
+    checklist_item_id INT NOT NULL,
+    checked_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE KEY unique_student_checklist_item (student_id, checklist_item_id),
+
+    CONSTRAINT fk_sci_student
+        FOREIGN KEY (student_id)
+        REFERENCES Students(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_sci_checklist_item
+        FOREIGN KEY (checklist_item_id)
+        REFERENCES ChecklistItems(id)
+        ON DELETE CASCADE
+);
+
+-- When set, ticking the last required item completes the subject
+ALTER TABLE Subjects
+ADD COLUMN auto_complete BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose down
+ALTER TABLE Subjects
+DROP COLUMN auto_complete;
+
+DROP TABLE IF EXISTS StudentChecklistItems;
+DROP TABLE IF EXISTS ChecklistItems;
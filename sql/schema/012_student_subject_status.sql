@@ -0,0 +1,45 @@
+-- +goose up
+CREATE TABLE StudentSubjectStatus (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    student_id INT NOT NULL,
+    subject_id INT NOT NULL,
+    status VARCHAR(32) NOT NULL DEFAULT 'not_started', -- not_started, in_progress, blocked, completed
+    started_at TIMESTAMP NULL,
+    blocked_reason VARCHAR(255),
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    UNIQUE KEY unique_student_subject_status (student_id, subject_id),
+
+    CONSTRAINT fk_sss_student
+        FOREIGN KEY (student_id)
+        REFERENCES Students(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_sss_subject
+        FOREIGN KEY (subject_id)
+        REFERENCES Subjects(id)
+        ON DELETE CASCADE
+);
+
+-- Optional start/stop time tracking; an open session has no stopped_at
+CREATE TABLE StudySessions (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    student_id INT NOT NULL,
+    subject_id INT NOT NULL,
+    started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    stopped_at TIMESTAMP NULL,
+
+    CONSTRAINT fk_ss_student
+        FOREIGN KEY (student_id)
+        REFERENCES Students(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_ss_subject
+        FOREIGN KEY (subject_id)
+        REFERENCES Subjects(id)
+        ON DELETE CASCADE
+);
+
+-- +goose down
+DROP TABLE IF EXISTS StudySessions;
+DROP TABLE IF EXISTS StudentSubjectStatus;
@@ -0,0 +1,42 @@
+-- +goose up
+-- recorded_at is when the row was entered, completed_at is when the student
+-- actually completed the subject (it may be backdated)
+ALTER TABLE StudentSubjectCompletion
+ADD COLUMN recorded_by_tutor_id INT NULL,
+ADD COLUMN recorded_by VARCHAR(255) NOT NULL DEFAULT '',
+ADD COLUMN recorded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+ADD CONSTRAINT fk_ssc_recorded_by_tutor
+    FOREIGN KEY (recorded_by_tutor_id)
+    REFERENCES Tutors(id)
+    ON DELETE SET NULL;
+
+CREATE TABLE CompletionAmendments (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    completion_id INT NOT NULL,
+    field VARCHAR(64) NOT NULL,
+    old_value VARCHAR(255),
+    new_value VARCHAR(255),
+    reason VARCHAR(255) NOT NULL,
+    amended_by_tutor_id INT NULL,
+    amended_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_ca_completion
+        FOREIGN KEY (completion_id)
+        REFERENCES StudentSubjectCompletion(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_ca_tutor
+        FOREIGN KEY (amended_by_tutor_id)
+        REFERENCES Tutors(id)
+        ON DELETE SET NULL
+);
+
+-- +goose down
+DROP TABLE IF EXISTS CompletionAmendments;
+
+ALTER TABLE StudentSubjectCompletion
+DROP FOREIGN KEY fk_ssc_recorded_by_tutor,
+DROP COLUMN recorded_by_tutor_id,
+DROP COLUMN recorded_by,
+DROP COLUMN recorded_at;
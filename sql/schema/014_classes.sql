@@ -0,0 +1,28 @@
+-- +goose up
+CREATE TABLE Classes (
+  id INT AUTO_INCREMENT PRIMARY KEY,
+  code VARCHAR(255) UNIQUE NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  position INT NOT NULL DEFAULT 0, -- order of the class in the ladder
+  description VARCHAR(255)
+);
+
+-- Normalise the free-form values so "F", "f" and "F " become one class
+UPDATE Subjects SET class = UPPER(TRIM(class));
+
+INSERT INTO Classes (code, name)
+SELECT DISTINCT class, class FROM Subjects;
+
+-- Subjects keep the class code, now constrained by Classes; renaming a class
+-- code carries over to its subjects
+ALTER TABLE Subjects
+ADD CONSTRAINT fk_subject_class
+    FOREIGN KEY (class)
+    REFERENCES Classes(code)
+    ON UPDATE CASCADE;
+
+-- +goose down
+ALTER TABLE Subjects
+DROP FOREIGN KEY fk_subject_class;
+
+DROP TABLE IF EXISTS Classes;
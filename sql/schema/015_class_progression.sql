@@ -0,0 +1,69 @@
+-- +goose up
+ALTER TABLE Students
+ADD COLUMN class VARCHAR(255) NULL,
+ADD CONSTRAINT fk_student_class
+    FOREIGN KEY (class)
+    REFERENCES Classes(code)
+    ON UPDATE CASCADE
+    ON DELETE SET NULL;
+
+-- A student advances to the next class once every rule of the current class
+-- holds. kind is 'all_subjects' (every subject of the class completed) or
+-- 'category_count' (at least min_count subjects of the class with the given
+-- category completed)
+CREATE TABLE ClassProgressionRules (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    class_id INT NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    category_id INT NULL,
+    min_count INT NOT NULL DEFAULT 0,
+
+    CONSTRAINT fk_cpr_class
+        FOREIGN KEY (class_id)
+        REFERENCES Classes(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_cpr_category
+        FOREIGN KEY (category_id)
+        REFERENCES Categories(id)
+        ON DELETE CASCADE
+);
+
+-- Class codes are copied so history survives classes being deleted
+CREATE TABLE StudentClassHistory (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    student_id INT NOT NULL,
+    from_class VARCHAR(255),
+    to_class VARCHAR(255),
+    reason VARCHAR(255) NOT NULL,
+    changed_by VARCHAR(255) NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_sch_student
+        FOREIGN KEY (student_id)
+        REFERENCES Students(id)
+        ON DELETE CASCADE
+);
+
+-- Outbox of domain events for clients to poll with GET /events?after=
+CREATE TABLE Events (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    kind VARCHAR(64) NOT NULL,
+    student_id INT NULL,
+    payload TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_event_student
+        FOREIGN KEY (student_id)
+        REFERENCES Students(id)
+        ON DELETE CASCADE
+);
+
+-- +goose down
+DROP TABLE IF EXISTS Events;
+DROP TABLE IF EXISTS StudentClassHistory;
+DROP TABLE IF EXISTS ClassProgressionRules;
+
+ALTER TABLE Students
+DROP FOREIGN KEY fk_student_class,
+DROP COLUMN class;
@@ -0,0 +1,13 @@
+-- +goose up
+-- Categories form a tree; deleting a category lifts its children to the top
+ALTER TABLE Categories
+ADD COLUMN parent_id INT NULL,
+ADD CONSTRAINT fk_category_parent
+    FOREIGN KEY (parent_id)
+    REFERENCES Categories(id)
+    ON DELETE SET NULL;
+
+-- +goose down
+ALTER TABLE Categories
+DROP FOREIGN KEY fk_category_parent,
+DROP COLUMN parent_id;
@@ -0,0 +1,50 @@
+-- +goose up
+CREATE TABLE LearningPaths (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(255) UNIQUE NOT NULL,
+    description VARCHAR(255)
+);
+
+-- Ordered subjects of a path; subjects may come from any class
+CREATE TABLE LearningPathSubjects (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    path_id INT NOT NULL,
+    subject_id INT NOT NULL,
+    position INT NOT NULL,
+
+    CONSTRAINT fk_lps_path
+        FOREIGN KEY (path_id)
+        REFERENCES LearningPaths(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_lps_subject
+        FOREIGN KEY (subject_id)
+        REFERENCES Subjects(id)
+        ON DELETE CASCADE,
+
+    UNIQUE (path_id, subject_id)
+);
+
+CREATE TABLE StudentLearningPaths (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    student_id INT NOT NULL,
+    path_id INT NOT NULL,
+    enrolled_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_slp_student
+        FOREIGN KEY (student_id)
+        REFERENCES Students(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_slp_path
+        FOREIGN KEY (path_id)
+        REFERENCES LearningPaths(id)
+        ON DELETE CASCADE,
+
+    UNIQUE (student_id, path_id)
+);
+
+-- +goose down
+DROP TABLE IF EXISTS StudentLearningPaths;
+DROP TABLE IF EXISTS LearningPathSubjects;
+DROP TABLE IF EXISTS LearningPaths;
@@ -0,0 +1,44 @@
+-- +goose up
+-- Snapshot of a subject's requirements from effective_from until the next
+-- revision; Subjects keeps mirroring the latest one
+CREATE TABLE SubjectRevisions (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    subject_id INT NOT NULL,
+    revision INT NOT NULL,
+    code VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description VARCHAR(255),
+    class VARCHAR(255) NOT NULL,
+    effective_from TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    changed_by VARCHAR(255) NOT NULL,
+
+    CONSTRAINT fk_sr_subject
+        FOREIGN KEY (subject_id)
+        REFERENCES Subjects(id)
+        ON DELETE CASCADE,
+
+    UNIQUE (subject_id, revision)
+);
+
+-- Completions are pinned to the revision current when they were granted
+ALTER TABLE StudentSubjectCompletion
+ADD COLUMN revision_id INT NULL,
+ADD CONSTRAINT fk_ssc_revision
+    FOREIGN KEY (revision_id)
+    REFERENCES SubjectRevisions(id)
+    ON DELETE SET NULL;
+
+-- Existing subjects start at revision 1 and existing completions refer to it
+INSERT INTO SubjectRevisions (subject_id, revision, code, name, description, class, changed_by)
+SELECT id, 1, code, name, description, class, 'migration' FROM Subjects;
+
+UPDATE StudentSubjectCompletion ssc
+JOIN SubjectRevisions sr ON sr.subject_id = ssc.subject_id AND sr.revision = 1
+SET ssc.revision_id = sr.id;
+
+-- +goose down
+ALTER TABLE StudentSubjectCompletion
+DROP FOREIGN KEY fk_ssc_revision,
+DROP COLUMN revision_id;
+
+DROP TABLE IF EXISTS SubjectRevisions;
@@ -0,0 +1,67 @@
+-- +goose up
+CREATE TABLE Terms (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(255) UNIQUE NOT NULL,
+    starts_on DATE NOT NULL,
+    ends_on DATE NOT NULL
+);
+
+CREATE TABLE Cohorts (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    term_id INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+
+    CONSTRAINT fk_cohort_term
+        FOREIGN KEY (term_id)
+        REFERENCES Terms(id)
+        ON DELETE CASCADE,
+
+    UNIQUE (term_id, name)
+);
+
+CREATE TABLE CohortStudents (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    cohort_id INT NOT NULL,
+    student_id INT NOT NULL,
+
+    CONSTRAINT fk_cs_cohort
+        FOREIGN KEY (cohort_id)
+        REFERENCES Cohorts(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_cs_student
+        FOREIGN KEY (student_id)
+        REFERENCES Students(id)
+        ON DELETE CASCADE,
+
+    UNIQUE (cohort_id, student_id)
+);
+
+-- Assignments and completions are tagged with the term they belong to;
+-- existing rows stay untagged
+ALTER TABLE StudentTutor
+ADD COLUMN term_id INT NULL,
+ADD CONSTRAINT fk_st_term
+    FOREIGN KEY (term_id)
+    REFERENCES Terms(id)
+    ON DELETE SET NULL;
+
+ALTER TABLE StudentSubjectCompletion
+ADD COLUMN term_id INT NULL,
+ADD CONSTRAINT fk_ssc_term
+    FOREIGN KEY (term_id)
+    REFERENCES Terms(id)
+    ON DELETE SET NULL;
+
+-- +goose down
+ALTER TABLE StudentSubjectCompletion
+DROP FOREIGN KEY fk_ssc_term,
+DROP COLUMN term_id;
+
+ALTER TABLE StudentTutor
+DROP FOREIGN KEY fk_st_term,
+DROP COLUMN term_id;
+
+DROP TABLE IF EXISTS CohortStudents;
+DROP TABLE IF EXISTS Cohorts;
+DROP TABLE IF EXISTS Terms;
@@ -0,0 +1,32 @@
+-- +goose up
+-- One of applicant, active, paused, graduated, dropped; the API enforces
+-- which transitions are allowed
+ALTER TABLE Students
+ADD COLUMN status VARCHAR(32) NOT NULL DEFAULT 'active';
+
+CREATE TABLE StudentStatusTransitions (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    student_id INT NOT NULL,
+    from_status VARCHAR(32) NOT NULL,
+    to_status VARCHAR(32) NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    changed_by_tutor_id INT NULL,
+    changed_by VARCHAR(255) NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_sst_student
+        FOREIGN KEY (student_id)
+        REFERENCES Students(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_sst_tutor
+        FOREIGN KEY (changed_by_tutor_id)
+        REFERENCES Tutors(id)
+        ON DELETE SET NULL
+);
+
+-- +goose down
+DROP TABLE IF EXISTS StudentStatusTransitions;
+
+ALTER TABLE Students
+DROP COLUMN status;
@@ -0,0 +1,41 @@
+-- +goose up
+ALTER TABLE Students
+ADD COLUMN email VARCHAR(255) UNIQUE,
+ADD COLUMN preferred_name VARCHAR(255),
+ADD COLUMN pronouns VARCHAR(64),
+ADD COLUMN timezone VARCHAR(64), -- IANA name, e.g. America/Sao_Paulo
+ADD COLUMN locale VARCHAR(35), -- BCP 47 tag, e.g. pt-BR
+ADD COLUMN enrolled_on DATE,
+ADD COLUMN bio TEXT,
+ADD COLUMN avatar_url VARCHAR(512);
+
+ALTER TABLE Tutors
+ADD COLUMN email VARCHAR(255) UNIQUE,
+ADD COLUMN preferred_name VARCHAR(255),
+ADD COLUMN pronouns VARCHAR(64),
+ADD COLUMN timezone VARCHAR(64),
+ADD COLUMN locale VARCHAR(35),
+ADD COLUMN enrolled_on DATE,
+ADD COLUMN bio TEXT,
+ADD COLUMN avatar_url VARCHAR(512);
+
+-- +goose down
+ALTER TABLE Tutors
+DROP COLUMN email,
+DROP COLUMN preferred_name,
+DROP COLUMN pronouns,
+DROP COLUMN timezone,
+DROP COLUMN locale,
+DROP COLUMN enrolled_on,
+DROP COLUMN bio,
+DROP COLUMN avatar_url;
+
+ALTER TABLE Students
+DROP COLUMN email,
+DROP COLUMN preferred_name,
+DROP COLUMN pronouns,
+DROP COLUMN timezone,
+DROP COLUMN locale,
+DROP COLUMN enrolled_on,
+DROP COLUMN bio,
+DROP COLUMN avatar_url;
@@ -0,0 +1,24 @@
+-- +goose up
+-- Categories a tutor can help with; proficiency goes from 1 (basic) to 5
+-- (expert)
+CREATE TABLE TutorCategories (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    tutor_id INT NOT NULL,
+    category_id INT NOT NULL,
+    proficiency INT NOT NULL DEFAULT 3,
+
+    CONSTRAINT fk_tc_tutor
+        FOREIGN KEY (tutor_id)
+        REFERENCES Tutors(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_tc_category
+        FOREIGN KEY (category_id)
+        REFERENCES Categories(id)
+        ON DELETE CASCADE,
+
+    UNIQUE (tutor_id, category_id)
+);
+
+-- +goose down
+DROP TABLE IF EXISTS TutorCategories;
@@ -0,0 +1,21 @@
+-- +goose up
+-- NULL means the tutor takes any number of students
+ALTER TABLE Tutors
+ADD COLUMN max_students INT NULL;
+
+-- Drop duplicate assignments, keeping the oldest, before making pairs unique
+DELETE st FROM StudentTutor st
+JOIN StudentTutor older
+    ON older.student_id = st.student_id
+    AND older.tutor_id = st.tutor_id
+    AND older.id < st.id;
+
+ALTER TABLE StudentTutor
+ADD CONSTRAINT uq_student_tutor UNIQUE (student_id, tutor_id);
+
+-- +goose down
+ALTER TABLE StudentTutor
+DROP INDEX uq_student_tutor;
+
+ALTER TABLE Tutors
+DROP COLUMN max_students;
@@ -0,0 +1,28 @@
+-- +goose up
+-- Assignments are ended instead of deleted, so past tutors stay on record
+ALTER TABLE StudentTutor
+ADD COLUMN ended_at TIMESTAMP NULL,
+ADD COLUMN end_reason VARCHAR(255) NULL,
+ADD COLUMN handoff_notes TEXT NULL,
+ADD COLUMN ended_by VARCHAR(255) NULL,
+-- TRUE while the assignment is running and NULL once it ends, so the unique
+-- key below only applies to active pairs
+ADD COLUMN active BOOLEAN AS (IF(ended_at IS NULL, TRUE, NULL)) STORED;
+
+ALTER TABLE StudentTutor
+DROP INDEX uq_student_tutor,
+ADD CONSTRAINT uq_active_student_tutor UNIQUE (student_id, tutor_id, active);
+
+-- +goose down
+DELETE FROM StudentTutor WHERE ended_at IS NOT NULL;
+
+ALTER TABLE StudentTutor
+DROP INDEX uq_active_student_tutor,
+ADD CONSTRAINT uq_student_tutor UNIQUE (student_id, tutor_id);
+
+ALTER TABLE StudentTutor
+DROP COLUMN active,
+DROP COLUMN ended_by,
+DROP COLUMN handoff_notes,
+DROP COLUMN end_reason,
+DROP COLUMN ended_at;
@@ -0,0 +1,28 @@
+-- +goose up
+-- role is one of primary, secondary or observer
+ALTER TABLE StudentTutor
+ADD COLUMN role VARCHAR(32) NOT NULL DEFAULT 'secondary',
+-- TRUE for an active primary assignment and NULL otherwise, so the unique
+-- key below allows one active primary tutor per student
+ADD COLUMN active_primary BOOLEAN AS (IF(role = 'primary' AND ended_at IS NULL, TRUE, NULL)) STORED;
+
+-- Each student's oldest active assignment becomes the primary one
+UPDATE StudentTutor st
+JOIN (
+    SELECT MIN(id) AS id
+    FROM StudentTutor
+    WHERE ended_at IS NULL
+    GROUP BY student_id
+) first_active ON first_active.id = st.id
+SET st.role = 'primary';
+
+ALTER TABLE StudentTutor
+ADD CONSTRAINT uq_active_primary UNIQUE (student_id, active_primary);
+
+-- +goose down
+ALTER TABLE StudentTutor
+DROP INDEX uq_active_primary;
+
+ALTER TABLE StudentTutor
+DROP COLUMN active_primary,
+DROP COLUMN role;
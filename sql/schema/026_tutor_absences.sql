@@ -0,0 +1,26 @@
+-- +goose up
+-- An absence covers [starts_at, ends_at); while it lasts the substitute also
+-- looks after the absent tutor's students. Rows are kept after they end.
+CREATE TABLE TutorAbsences (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    tutor_id INT NOT NULL,
+    substitute_id INT NULL,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_absence_tutor
+        FOREIGN KEY (tutor_id)
+        REFERENCES Tutors(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_absence_substitute
+        FOREIGN KEY (substitute_id)
+        REFERENCES Tutors(id)
+        ON DELETE SET NULL
+);
+
+-- +goose down
+DROP TABLE IF EXISTS TutorAbsences;
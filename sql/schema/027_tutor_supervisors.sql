@@ -0,0 +1,13 @@
+-- +goose up
+-- Head tutors supervise other tutors; a tutor has at most one supervisor
+ALTER TABLE Tutors
+ADD COLUMN supervisor_id INT NULL,
+ADD CONSTRAINT fk_tutor_supervisor
+    FOREIGN KEY (supervisor_id)
+    REFERENCES Tutors(id)
+    ON DELETE SET NULL;
+
+-- +goose down
+ALTER TABLE Tutors
+DROP FOREIGN KEY fk_tutor_supervisor,
+DROP COLUMN supervisor_id;
@@ -0,0 +1,39 @@
+-- +goose up
+-- status is one of pending, accepted, declined or expired
+CREATE TABLE TutorRequests (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    student_id INT NOT NULL,
+    tutor_id INT NULL,
+    category_id INT NULL,
+    message TEXT NULL,
+    status VARCHAR(32) NOT NULL DEFAULT 'pending',
+    decision_reason VARCHAR(255) NULL,
+    decided_by VARCHAR(255) NULL,
+    decided_at TIMESTAMP NULL,
+    assignment_id INT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+
+    CONSTRAINT fk_request_student
+        FOREIGN KEY (student_id)
+        REFERENCES Students(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_request_tutor
+        FOREIGN KEY (tutor_id)
+        REFERENCES Tutors(id)
+        ON DELETE SET NULL,
+
+    CONSTRAINT fk_request_category
+        FOREIGN KEY (category_id)
+        REFERENCES Categories(id)
+        ON DELETE SET NULL,
+
+    CONSTRAINT fk_request_assignment
+        FOREIGN KEY (assignment_id)
+        REFERENCES StudentTutor(id)
+        ON DELETE SET NULL
+);
+
+-- +goose down
+DROP TABLE IF EXISTS TutorRequests;
@@ -0,0 +1,38 @@
+-- +goose up
+-- status is one of open, claimed or resolved. queued_at is when the request
+-- last joined the queue; wait and handle times add up across hand-backs.
+CREATE TABLE HelpRequests (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    student_id INT NOT NULL,
+    subject_id INT NOT NULL,
+    message TEXT NULL,
+    priority INT NOT NULL DEFAULT 0,
+    status VARCHAR(32) NOT NULL DEFAULT 'open',
+    tutor_id INT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    queued_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    claimed_at TIMESTAMP NULL,
+    resolved_at TIMESTAMP NULL,
+    wait_seconds INT NOT NULL DEFAULT 0,
+    handle_seconds INT NOT NULL DEFAULT 0,
+    handbacks INT NOT NULL DEFAULT 0,
+    resolution TEXT NULL,
+
+    CONSTRAINT fk_help_student
+        FOREIGN KEY (student_id)
+        REFERENCES Students(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_help_subject
+        FOREIGN KEY (subject_id)
+        REFERENCES Subjects(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_help_tutor
+        FOREIGN KEY (tutor_id)
+        REFERENCES Tutors(id)
+        ON DELETE SET NULL
+);
+
+-- +goose down
+DROP TABLE IF EXISTS HelpRequests;
@@ -0,0 +1,48 @@
+-- +goose up
+-- visibility is one of tutor (the student's tutors only), staff or student.
+-- Notes hang off a student and optionally one of their completions.
+CREATE TABLE Notes (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    student_id INT NOT NULL,
+    completion_id INT NULL,
+    author_tutor_id INT NULL,
+    author VARCHAR(255) NOT NULL,
+    visibility VARCHAR(32) NOT NULL DEFAULT 'tutor',
+    body TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_note_student
+        FOREIGN KEY (student_id)
+        REFERENCES Students(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_note_completion
+        FOREIGN KEY (completion_id)
+        REFERENCES StudentSubjectCompletion(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_note_author
+        FOREIGN KEY (author_tutor_id)
+        REFERENCES Tutors(id)
+        ON DELETE SET NULL
+);
+
+-- The previous version of a note, saved each time it is edited
+CREATE TABLE NoteRevisions (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    note_id INT NOT NULL,
+    body TEXT NOT NULL,
+    visibility VARCHAR(32) NOT NULL,
+    edited_by VARCHAR(255) NOT NULL,
+    edited_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_revision_note
+        FOREIGN KEY (note_id)
+        REFERENCES Notes(id)
+        ON DELETE CASCADE
+);
+
+-- +goose down
+DROP TABLE IF EXISTS NoteRevisions;
+DROP TABLE IF EXISTS Notes;
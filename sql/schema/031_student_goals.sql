@@ -0,0 +1,37 @@
+-- +goose up
+-- A goal targets either one subject or every subject of a class, to be
+-- passed by the end of target_date
+CREATE TABLE StudentGoals (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    student_id INT NOT NULL,
+    subject_id INT NULL,
+    class VARCHAR(255) NULL,
+    target_date DATE NOT NULL,
+    set_by_tutor_id INT NULL,
+    set_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_goal_student
+        FOREIGN KEY (student_id)
+        REFERENCES Students(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_goal_subject
+        FOREIGN KEY (subject_id)
+        REFERENCES Subjects(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_goal_class
+        FOREIGN KEY (class)
+        REFERENCES Classes(code)
+        ON UPDATE CASCADE
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_goal_tutor
+        FOREIGN KEY (set_by_tutor_id)
+        REFERENCES Tutors(id)
+        ON DELETE SET NULL
+);
+
+-- +goose down
+DROP TABLE IF EXISTS StudentGoals;
@@ -0,0 +1,49 @@
+-- +goose up
+-- rule is one of completions (at least threshold passed subjects, within
+-- category_id when set), category (every subject of category_id passed) or
+-- streak (at least threshold consecutive weeks with a passed subject)
+CREATE TABLE Badges (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    code VARCHAR(64) UNIQUE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NULL,
+    rule VARCHAR(32) NOT NULL,
+    category_id INT NULL,
+    threshold INT NOT NULL DEFAULT 1,
+    announce BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_badge_category
+        FOREIGN KEY (category_id)
+        REFERENCES Categories(id)
+        ON DELETE CASCADE
+);
+
+CREATE TABLE StudentBadges (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    student_id INT NOT NULL,
+    badge_id INT NOT NULL,
+    completion_id INT NULL,
+    awarded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_sb_student
+        FOREIGN KEY (student_id)
+        REFERENCES Students(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_sb_badge
+        FOREIGN KEY (badge_id)
+        REFERENCES Badges(id)
+        ON DELETE CASCADE,
+
+    CONSTRAINT fk_sb_completion
+        FOREIGN KEY (completion_id)
+        REFERENCES StudentSubjectCompletion(id)
+        ON DELETE SET NULL,
+
+    UNIQUE (student_id, badge_id)
+);
+
+-- +goose down
+DROP TABLE IF EXISTS StudentBadges;
+DROP TABLE IF EXISTS Badges;
@@ -0,0 +1,23 @@
+-- +goose up
+-- Classes backfilled from subjects all sat at position 0, which left the
+-- ladder without a next class. Place the known ladder, F first and A last,
+-- and keep positions distinct from now on. Any other class still sharing a
+-- position makes the constraint below fail: its place in the ladder cannot
+-- be guessed, so set it by hand and run the migration again.
+UPDATE Classes
+SET position = CASE code
+    WHEN 'F' THEN 1
+    WHEN 'E' THEN 2
+    WHEN 'D' THEN 3
+    WHEN 'C' THEN 4
+    WHEN 'B' THEN 5
+    WHEN 'A' THEN 6
+END
+WHERE position = 0 AND code IN ('F', 'E', 'D', 'C', 'B', 'A');
+
+ALTER TABLE Classes
+ADD CONSTRAINT uq_class_position UNIQUE (position);
+
+-- +goose down
+ALTER TABLE Classes
+DROP INDEX uq_class_position;
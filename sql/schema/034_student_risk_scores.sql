@@ -0,0 +1,18 @@
+-- +goose up
+-- latest at-risk score per active student, written by the scoring job;
+-- detail holds the factors behind the score as JSON
+CREATE TABLE StudentRiskScores (
+    student_id INT PRIMARY KEY,
+    level VARCHAR(16) NOT NULL,
+    score INT NOT NULL,
+    detail TEXT NOT NULL,
+    scored_at TIMESTAMP NOT NULL,
+
+    CONSTRAINT fk_srs_student
+        FOREIGN KEY (student_id)
+        REFERENCES Students(id)
+        ON DELETE CASCADE
+);
+
+-- +goose down
+DROP TABLE IF EXISTS StudentRiskScores;